@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+
+// Package backend defines the interface kdlc uses to run backends, whether
+// they're built into the compiler or run as separate ckdl-to-* commands.
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"runtime/debug"
+
+	"google.golang.org/protobuf/proto"
+
+	"k8s.io/idl/backends/common/request"
+	"k8s.io/idl/backends/common/respond"
+	irb "k8s.io/idl/ckdl-ir/goir/backend"
+)
+
+// Backend generates output from compiled cKDL.
+type Backend interface {
+	// Generate runs the backend on the given request, sending files & logs
+	// to out as they're produced.  Problems with the input should be logged
+	// as errors; the returned error is for when the backend couldn't run
+	// to completion.  kdlc may run several backends at once on the same
+	// request contents, so backends mustn't modify the request.
+	Generate(ctx context.Context, req *irb.Request, out respond.Sink) error
+}
+
+// ErrReported is returned by backends that stopped because of errors
+// they've already logged.
+var ErrReported = errors.New("backend reported errors")
+
+// Func is a backend written in Go, which works on the decoded request.
+type Func func(ctx context.Context, req *request.Request, resp *respond.Responder) error
+
+// Generate decodes the request & runs the backend on it.  Backends run
+// inside kdlc, so a panic is returned as an error rather than taking the
+// compiler (and every other backend) down with it.
+func (f Func) Generate(ctx context.Context, req *irb.Request, out respond.Sink) (retErr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			retErr = fmt.Errorf("backend panicked: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	decoded, err := request.FromProto(req)
+	if err != nil {
+		return err
+	}
+	resp := respond.New(out)
+	resp.Nodes = respond.IndexNodes(req.Bundle)
+	return f(ctx, decoded, resp)
+}
+
+// Main runs the given backend as a ckdl-to-* command, reading the request
+// from stdin and writing responses to stdout.  It exits non-zero if the
+// backend fails.
+func Main(b Backend) {
+	contents, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		respond.GeneralError(err, "unable to read backend request")
+		os.Exit(1)
+	}
+	var req irb.Request
+	if err := proto.Unmarshal(contents, &req); err != nil {
+		respond.GeneralError(err, "unable to decode backend request")
+		os.Exit(1)
+	}
+
+	if err := b.Generate(context.Background(), &req, respond.Stdout); err != nil {
+		if !errors.Is(err, ErrReported) {
+			respond.GeneralError(err, "unable to generate")
+		}
+		os.Exit(1)
+	}
+}
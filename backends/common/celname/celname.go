@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+
+// Package celname escapes field names for use in CEL validation rules, the
+// way the API server expects, so that the compiler (which checks rules)
+// and backends (which write them) agree on what a field is called.
+package celname
+
+import (
+	"strings"
+)
+
+// reserved are the identifiers that must be escaped (as `__kw__`) when used
+// as field names in CEL rules.
+var reserved = map[string]bool{
+	"true": true, "false": true, "null": true, "in": true, "as": true,
+	"break": true, "const": true, "continue": true, "else": true,
+	"for": true, "function": true, "if": true, "import": true, "let": true,
+	"loop": true, "package": true, "namespace": true, "return": true,
+	"var": true, "void": true, "while": true,
+}
+
+// Escape escapes a field name that isn't a valid CEL identifier (e.g.
+// `foo-bar` is written `foo__dash__bar`).
+func Escape(name string) string {
+	if reserved[name] {
+		return "__" + name + "__"
+	}
+	return strings.NewReplacer(
+		"__", "__underscores__",
+		".", "__dot__",
+		"-", "__dash__",
+		"/", "__slash__",
+	).Replace(name)
+}
+
+// Unescape undoes Escape.
+func Unescape(name string) string {
+	if strings.HasPrefix(name, "__") && strings.HasSuffix(name, "__") && reserved[strings.Trim(name, "_")] {
+		return strings.Trim(name, "_")
+	}
+	return strings.NewReplacer(
+		"__underscores__", "__",
+		"__dot__", ".",
+		"__dash__", "-",
+		"__slash__", "/",
+	).Replace(name)
+}
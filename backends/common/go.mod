@@ -5,7 +5,9 @@ go 1.15
 require (
 	github.com/golang/protobuf v1.4.3
 	google.golang.org/protobuf v1.25.0
+	gopkg.in/yaml.v2 v2.4.0
 	k8s.io/idl/ckdl-ir/goir v0.0.0-00010101000000-000000000000
+	sigs.k8s.io/structured-merge-diff/v4 v4.1.0
 )
 
 replace k8s.io/idl/ckdl-ir/goir => ../../ckdl-ir/goir
@@ -37,6 +37,8 @@ type Loader struct {
 	// byGV maps group-versions to the partials that describe that group-version
 	byGV map[GroupVersion][]GroupVersionInfo
 }
+
+// NewLoader loads a serialized bundle from the given reader.
 func NewLoader(src io.Reader) (*Loader, error) {
 	contents, err := ioutil.ReadAll(src)
 	if err != nil {
@@ -46,6 +48,11 @@ func NewLoader(src io.Reader) (*Loader, error) {
 	if err := proto.Unmarshal(contents, &bundle); err != nil {
 		return nil, fmt.Errorf("unable to load cKDL bundle: %w", err)
 	}
+	return NewBundleLoader(&bundle), nil
+}
+
+// NewBundleLoader loads from an already-decoded bundle.
+func NewBundleLoader(bundle *ir.Bundle) *Loader {
 	l := &Loader{
 		byPath: make(map[string]*ir.Partial, len(bundle.VirtualFiles)),
 		byGV: make(map[GroupVersion][]GroupVersionInfo),
@@ -61,7 +68,7 @@ func NewLoader(src io.Reader) (*Loader, error) {
 			})
 		}
 	}
-	return l, nil
+	return l
 }
 
 func (l *Loader) Load(path string) (*ir.Partial, error) {
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package request
+
+import (
+	"fmt"
+	"strconv"
+
+	irb "k8s.io/idl/ckdl-ir/goir/backend"
+)
+
+// Options holds the backend-specific parameters from a request, in the
+// order they were passed.  Parameters may be repeated: the single-value
+// getters use the last value, while Strings returns all of them.
+type Options struct {
+	params []*irb.Parameter
+}
+
+// Names returns the name of each parameter that was passed, once each.
+func (o Options) Names() []string {
+	var res []string
+	seen := make(map[string]bool)
+	for _, param := range o.params {
+		if !seen[param.Name] {
+			seen[param.Name] = true
+			res = append(res, param.Name)
+		}
+	}
+	return res
+}
+
+// CheckKnown returns an error if any parameter isn't one of the given
+// names, to catch typos.
+func (o Options) CheckKnown(names ...string) error {
+	known := make(map[string]bool, len(names))
+	for _, name := range names {
+		known[name] = true
+	}
+	for _, param := range o.params {
+		if !known[param.Name] {
+			return fmt.Errorf("unknown option %q", param.Name)
+		}
+	}
+	return nil
+}
+
+func (o Options) last(name string) *irb.Parameter {
+	for i := len(o.params) - 1; i >= 0; i-- {
+		if o.params[i].Name == name {
+			return o.params[i]
+		}
+	}
+	return nil
+}
+
+// Strings returns every value passed for the given parameter, in order,
+// converting bools and ints to strings.
+func (o Options) Strings(name string) []string {
+	var res []string
+	for _, param := range o.params {
+		if param.Name == name {
+			res = append(res, paramString(param))
+		}
+	}
+	return res
+}
+
+// String returns the last value of the given parameter as a string, or
+// the default if it wasn't passed.
+func (o Options) String(name, defaultVal string) string {
+	param := o.last(name)
+	if param == nil {
+		return defaultVal
+	}
+	return paramString(param)
+}
+
+// Bool returns the last value of the given parameter as a bool, or the
+// default if it wasn't passed.  String values are parsed.
+func (o Options) Bool(name string, defaultVal bool) (bool, error) {
+	param := o.last(name)
+	if param == nil {
+		return defaultVal, nil
+	}
+	switch val := param.Value.(type) {
+	case *irb.Parameter_Bool:
+		return val.Bool, nil
+	case *irb.Parameter_Str:
+		res, err := strconv.ParseBool(val.Str)
+		if err != nil {
+			return false, fmt.Errorf("invalid value for option %q: %w", name, err)
+		}
+		return res, nil
+	default:
+		return false, fmt.Errorf("option %q must be true or false", name)
+	}
+}
+
+// Int returns the last value of the given parameter as an int, or the
+// default if it wasn't passed.  String values are parsed.
+func (o Options) Int(name string, defaultVal int64) (int64, error) {
+	param := o.last(name)
+	if param == nil {
+		return defaultVal, nil
+	}
+	switch val := param.Value.(type) {
+	case *irb.Parameter_Int:
+		return val.Int, nil
+	case *irb.Parameter_Str:
+		res, err := strconv.ParseInt(val.Str, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid value for option %q: %w", name, err)
+		}
+		return res, nil
+	default:
+		return 0, fmt.Errorf("option %q must be an integer", name)
+	}
+}
+
+func paramString(param *irb.Parameter) string {
+	switch val := param.Value.(type) {
+	case *irb.Parameter_Bool:
+		return strconv.FormatBool(val.Bool)
+	case *irb.Parameter_Int:
+		return strconv.FormatInt(val.Int, 10)
+	default:
+		return param.GetStr()
+	}
+}
+
+// ParseParameter turns a `name=value` string into a parameter, typing the
+// value as a bool or int if it looks like one.
+func ParseParameter(name, value string) *irb.Parameter {
+	param := &irb.Parameter{Name: name}
+	if value == "true" || value == "false" {
+		param.Value = &irb.Parameter_Bool{Bool: value == "true"}
+	} else if intVal, err := strconv.ParseInt(value, 10, 64); err == nil && strconv.FormatInt(intVal, 10) == value {
+		param.Value = &irb.Parameter_Int{Int: intVal}
+	} else {
+		param.Value = &irb.Parameter_Str{Str: value}
+	}
+	return param
+}
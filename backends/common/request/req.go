@@ -5,9 +5,9 @@ package request
 import (
 	"fmt"
 	"strings"
-	"os"
 
-	"k8s.io/idl/backends/common/respond"
+	irb "k8s.io/idl/ckdl-ir/goir/backend"
+	irt "k8s.io/idl/ckdl-ir/goir/types"
 )
 
 type TypeIdent struct {
@@ -17,6 +17,14 @@ func (t TypeIdent) String() string {
 	return fmt.Sprintf("%s/%s::%s", t.Group, t.Version, t.Type)
 }
 
+// Ref converts the identifier to an IR reference, as used in requests.
+func (t TypeIdent) Ref() *irt.Reference {
+	return &irt.Reference{
+		GroupVersion: &irt.GroupVersionRef{Group: t.Group, Version: t.Version},
+		Name:         t.Type,
+	}
+}
+
 
 func ParseTypes(typesRaw ...string) ([]TypeIdent, error) {
 	var res []TypeIdent
@@ -39,18 +47,45 @@ func ParseTypes(typesRaw ...string) ([]TypeIdent, error) {
 	return res, nil
 }
 
-func Parse() (*Loader, []TypeIdent) {
-	loader, err := NewLoader(os.Stdin)
-	if err != nil {
-		respond.GeneralError(err, "unable to load cKDL bundle")
-		os.Exit(1)
-	}
+// Request is a decoded backend request (see the backend.Request proto).
+type Request struct {
+	// Loader loads IR from the request's bundle.
+	Loader *Loader
+	// Roots are the paths of the files the compiler was asked to compile,
+	// as opposed to their dependencies.
+	Roots []string
+	// Types are the specific types to generate for.  Empty means everything.
+	Types []TypeIdent
+	// Options are the backend-specific parameters.
+	Options Options
+	// OutputDir is where the compiler will write the backend's files.
+	OutputDir string
+	// CompilerVersion is the version of the compiler that sent the request.
+	CompilerVersion string
+}
 
-	types, err := ParseTypes(os.Args[1:]...)
-	if err != nil {
-		respond.GeneralError(err, "unable to parse type arguments")
-		os.Exit(1)
+// FromProto decodes a backend.Request, as sent by the compiler.
+func FromProto(req *irb.Request) (*Request, error) {
+	if req.Bundle == nil {
+		return nil, fmt.Errorf("backend request has no cKDL bundle")
 	}
 
-	return loader, types
+	res := &Request{
+		Loader:          NewBundleLoader(req.Bundle),
+		Roots:           req.Roots,
+		Options:         Options{params: req.Parameters},
+		OutputDir:       req.OutputDir,
+		CompilerVersion: req.CompilerVersion,
+	}
+	for _, ref := range req.Types {
+		if ref.GroupVersion == nil {
+			return nil, fmt.Errorf("requested type %q has no group-version", ref.Name)
+		}
+		res.Types = append(res.Types, TypeIdent{
+			Group:   ref.GroupVersion.Group,
+			Version: ref.GroupVersion.Version,
+			Type:    ref.Name,
+		})
+	}
+	return res, nil
 }
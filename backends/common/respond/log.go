@@ -5,45 +5,109 @@ package respond
 import (
 	"fmt"
 
+	"google.golang.org/protobuf/proto"
+
 	ir "k8s.io/idl/ckdl-ir/goir/backend"
 )
 
 // TODO: actual structured responses here
 
-// TODO: XYZAt logs
 // TODO: allow switching output format
 
-func genMsg(lvl ir.Log_Level, loggedErr error, msg string, kvPairs ...interface{}) {
+func (r *Responder) genMsg(lvl ir.Log_Level, node *Tracker, loggedErr error, msg string, kvPairs ...interface{}) {
 	numPairs := len(kvPairs)/2 + len(kvPairs)%2
 	logPairs := make([]*ir.Log_Trace_KeyValue, numPairs)
 	for i, item := range kvPairs {
 		pairInd := i/2
 		if i % 2 == 0 {
 			logPairs[pairInd] = &ir.Log_Trace_KeyValue{Key: item.(string)}
-		} else {
-			// TODO: OtherNode
-			logPairs[pairInd].Value = &ir.Log_Trace_KeyValue_Str{Str: fmt.Sprintf("%v", item)}
+			continue
+		}
+		// trackers & IR nodes from the request point at other nodes, which
+		// the compiler can show the source of
+		if otherNode := r.asNode(item); otherNode != nil {
+			logPairs[pairInd].Value = &ir.Log_Trace_KeyValue_OtherNode{OtherNode: otherNode.node()}
+			continue
 		}
+		logPairs[pairInd].Value = &ir.Log_Trace_KeyValue_Str{Str: fmt.Sprintf("%v", item)}
 	}
 	line := ir.Log{
 		Lvl: lvl,
-		Trace: []*ir.Log_Trace{{Message: msg, Values: logPairs}},
+		Trace: []*ir.Log_Trace{{Message: msg, Values: logPairs, Node: node.node()}},
 	}
 	if loggedErr != nil {
 		line.Trace = append(line.Trace, &ir.Log_Trace{Message: loggedErr.Error()})
 	}
 
-	Write(&ir.Response{Type: &ir.Response_Log{Log: &line}})
+	r.Write(&ir.Response{Type: &ir.Response_Log{Log: &line}})
 	// panic last so we get the log out
 	if len(kvPairs) % 2 != 0 {
 		panic("uneven number of key-value items specified in log line")
 	}
 }
 
+// asNode returns the tracker for a key-value value that's a node, or nil if
+// it isn't one.
+func (r *Responder) asNode(item interface{}) *Tracker {
+	switch node := item.(type) {
+	case *Tracker:
+		return node
+	case proto.Message:
+		return r.Node(node)
+	default:
+		return nil
+	}
+}
+
+func (r *Responder) GeneralInfo(msg string, kvPairs ...interface{}) {
+	r.genMsg(ir.Log_INFO, nil, nil, msg, kvPairs...)
+}
+
+func (r *Responder) GeneralError(err error, msg string, kvPairs ...interface{}) {
+	r.genMsg(ir.Log_ERROR, nil, err, msg, kvPairs...)
+}
+
+// Warn logs a problem that doesn't stop the backend from producing
+// correct output.
+func (r *Responder) Warn(msg string, kvPairs ...interface{}) {
+	r.genMsg(ir.Log_WARNING, nil, nil, msg, kvPairs...)
+}
+
+// Debug logs details that are only shown when the compiler is run
+// verbosely.
+func (r *Responder) Debug(msg string, kvPairs ...interface{}) {
+	r.genMsg(ir.Log_DEBUG, nil, nil, msg, kvPairs...)
+}
+
+// InfoAt logs information about the given node from the request.
+func (r *Responder) InfoAt(node *Tracker, msg string, kvPairs ...interface{}) {
+	r.genMsg(ir.Log_INFO, node, nil, msg, kvPairs...)
+}
+
+// WarnAt logs a warning about the given node from the request.
+func (r *Responder) WarnAt(node *Tracker, msg string, kvPairs ...interface{}) {
+	r.genMsg(ir.Log_WARNING, node, nil, msg, kvPairs...)
+}
+
+// ErrorAt logs an error caused by the given node from the request, so that
+// the compiler can point at the KDL responsible.  A nil node is allowed,
+// for when the node couldn't be found.
+func (r *Responder) ErrorAt(node *Tracker, err error, msg string, kvPairs ...interface{}) {
+	r.genMsg(ir.Log_ERROR, node, err, msg, kvPairs...)
+}
+
 func GeneralInfo(msg string, kvPairs ...interface{}) {
-	genMsg(ir.Log_INFO, nil, msg, kvPairs...)
+	stdout.GeneralInfo(msg, kvPairs...)
 }
 
 func GeneralError(err error, msg string, kvPairs ...interface{}) {
-	genMsg(ir.Log_ERROR, err, msg, kvPairs...)
+	stdout.GeneralError(err, msg, kvPairs...)
+}
+
+func Warn(msg string, kvPairs ...interface{}) {
+	stdout.Warn(msg, kvPairs...)
+}
+
+func Debug(msg string, kvPairs ...interface{}) {
+	stdout.Debug(msg, kvPairs...)
 }
@@ -3,6 +3,12 @@
 package respond
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
 	"os"
 
 	"google.golang.org/protobuf/proto"
@@ -11,17 +17,54 @@ import (
 	ir "k8s.io/idl/ckdl-ir/goir/backend"
 )
 
-func Write(msg *ir.Response) {
-	out := protowire.AppendVarint(nil, uint64(proto.Size(msg)))
+// Sink receives the responses from a backend, in the order they're sent.
+type Sink func(msg *ir.Response)
+
+// Responder sends files & logs to a sink.  Backends that run in-process
+// are handed one, while the package-level functions use one that writes
+// to stdout, for backends that run as their own command.
+type Responder struct {
+	sink Sink
+
+	// Nodes, if set, finds trackers for the nodes in the request's bundle
+	// (see Node).
+	Nodes *NodeIndex
+}
+
+// New returns a responder that sends everything to the given sink.
+func New(sink Sink) *Responder {
+	return &Responder{sink: sink}
+}
+
+// Stdout writes each response to stdout, in the form Read expects.
+func Stdout(msg *ir.Response) {
+	os.Stdout.Write(Encode(nil, msg))
+}
+
+var stdout = New(Stdout)
+
+// Encode appends the length-prefixed form of the given response to out.
+func Encode(out []byte, msg *ir.Response) []byte {
+	out = protowire.AppendVarint(out, uint64(proto.Size(msg)))
 	out, err := proto.MarshalOptions{}.MarshalAppend(out, msg)
 	if err != nil {
 		panic(err)
 	}
-	os.Stdout.Write(out)
+	return out
 }
 
-func File(path string, contents []byte) {
-	Write(&ir.Response{
+// Node returns the tracker for the given node from the request, for use
+// with the XYZAt logging methods, or nil if it can't be found.
+func (r *Responder) Node(node proto.Message) *Tracker {
+	return r.Nodes.Find(node)
+}
+
+func (r *Responder) Write(msg *ir.Response) {
+	r.sink(msg)
+}
+
+func (r *Responder) File(path string, contents []byte) {
+	r.Write(&ir.Response{
 		Type: &ir.Response_Result{Result: &ir.File{
 			Name: path,
 			Contents: contents,
@@ -29,15 +72,79 @@ func File(path string, contents []byte) {
 	})
 }
 
-func Read(msg *ir.Response, from []byte) []byte {
+func Write(msg *ir.Response) {
+	stdout.Write(msg)
+}
+
+func File(path string, contents []byte) {
+	stdout.File(path, contents)
+}
+
+// maxResponseSize is the largest response we'll read, which is the most
+// protobuf can encode in a single message.
+const maxResponseSize = math.MaxInt32
+
+// Read decodes the first response (as written by Encode) from the given
+// bytes, returning the rest of them.  Truncated or corrupt responses are
+// an error.
+func Read(msg *ir.Response, from []byte) ([]byte, error) {
 	size, sizeSize := protowire.ConsumeVarint(from)
-	if size < 0 {
-		panic("unable to read backend response size")
+	if sizeSize < 0 {
+		return from, fmt.Errorf("unable to read backend response size: %w", protowire.ParseError(sizeSize))
+	}
+	if size > maxResponseSize {
+		return from, fmt.Errorf("backend response size %d is too large, the response is probably corrupt", size)
 	}
 
 	from = from[sizeSize:]
+	if uint64(len(from)) < size {
+		return from, fmt.Errorf("truncated backend response (expected %d bytes, got %d): %w", size, len(from), io.ErrUnexpectedEOF)
+	}
 	if err := proto.Unmarshal(from[:size], msg); err != nil {
-		panic(err)
+		return from, fmt.Errorf("unable to decode backend response: %w", err)
+	}
+	return from[size:], nil
+}
+
+// Decoder reads responses (as written by Encode) from a stream as they
+// arrive, so that they needn't all be held in memory at once.
+type Decoder struct {
+	in *bufio.Reader
+	buf bytes.Buffer
+}
+
+// NewDecoder returns a decoder that reads from the given stream.
+func NewDecoder(in io.Reader) *Decoder {
+	return &Decoder{in: bufio.NewReader(in)}
+}
+
+// Decode reads the next response into msg.  It returns io.EOF when the
+// stream ends cleanly between responses, and an error wrapping
+// io.ErrUnexpectedEOF if it ends partway through one.
+func (d *Decoder) Decode(msg *ir.Response) error {
+	size, err := binary.ReadUvarint(d.in)
+	switch {
+	case err == io.EOF:
+		return err
+	case err == io.ErrUnexpectedEOF:
+		return fmt.Errorf("truncated backend response size: %w", err)
+	case err != nil:
+		return fmt.Errorf("unable to read backend response size: %w", err)
+	case size > maxResponseSize:
+		return fmt.Errorf("backend response size %d is too large, the response is probably corrupt", size)
+	}
+
+	// copy instead of allocating the whole thing up front, so that a
+	// corrupt size doesn't have us allocate gigabytes for nothing
+	d.buf.Reset()
+	read, err := io.CopyN(&d.buf, d.in, int64(size))
+	if err == io.EOF {
+		return fmt.Errorf("truncated backend response (expected %d bytes, got %d): %w", size, read, io.ErrUnexpectedEOF)
+	} else if err != nil {
+		return fmt.Errorf("unable to read backend response: %w", err)
+	}
+	if err := proto.Unmarshal(d.buf.Bytes(), msg); err != nil {
+		return fmt.Errorf("unable to decode backend response: %w", err)
 	}
-	return from[size:]
+	return nil
 }
@@ -5,22 +5,28 @@ package respond
 import (
 	"fmt"
 
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 
 	ir "k8s.io/idl/ckdl-ir/goir"
+	irb "k8s.io/idl/ckdl-ir/goir/backend"
 )
 
 const noLoc = -1
 
+// Tracker builds the IR path to a node in the request's bundle, for logs
+// to point at.  Paths are the proto field numbers & list indices leading
+// from the bundle to the node, like the paths in a partial's source map
+// (which is how the compiler finds the KDL responsible).
 type Tracker struct {
 	parent *Tracker
 	current protoreflect.MessageDescriptor
 	loc int32
 	rep bool
 }
-func TrackSrc(file *ir.Partial) *Tracker {
+func TrackBundle() *Tracker {
 	return &Tracker{
-		current: file.ProtoReflect().Descriptor(),
+		current: (&ir.Bundle{}).ProtoReflect().Descriptor(),
 		loc: noLoc, // sentinel, avoid us
 	}
 }
@@ -51,3 +57,87 @@ func (m *Tracker) Item(ind int) *Tracker {
 		parent: m,
 	}
 }
+
+// Path returns the full path to the tracked node.  A nil tracker has no
+// path.
+func (m *Tracker) Path() []int32 {
+	var path []int32
+	for current := m; current != nil; current = current.parent {
+		if current.loc == noLoc {
+			continue
+		}
+		path = append(path, current.loc)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+func (m *Tracker) node() *irb.Log_Trace_Node {
+	if m == nil {
+		return nil
+	}
+	return &irb.Log_Trace_Node{Path: m.Path()}
+}
+
+// NodeIndex finds trackers for the nodes in a bundle, for backends that
+// have the nodes themselves at hand rather than how they got to them.
+// Nodes are found by pointer, so they must come from the indexed bundle
+// (as everything from the request's Loader does), not be copies.
+type NodeIndex struct {
+	nodes map[proto.Message]*Tracker
+	files map[string]*Tracker
+}
+
+// IndexNodes indexes every message in the given bundle.
+func IndexNodes(bundle *ir.Bundle) *NodeIndex {
+	idx := &NodeIndex{
+		nodes: make(map[proto.Message]*Tracker),
+		files: make(map[string]*Tracker, len(bundle.VirtualFiles)),
+	}
+	files := TrackBundle().Field("virtual_files")
+	for i, file := range bundle.VirtualFiles {
+		idx.files[file.Name] = files.Item(i).Field("contents")
+	}
+	idx.walk(bundle.ProtoReflect(), TrackBundle())
+	return idx
+}
+
+func (x *NodeIndex) walk(msg protoreflect.Message, tracker *Tracker) {
+	x.nodes[msg.Interface()] = tracker
+	msg.Range(func(field protoreflect.FieldDescriptor, val protoreflect.Value) bool {
+		if field.Message() == nil || field.IsMap() {
+			// source maps don't point into maps, so neither do we
+			return true
+		}
+		fieldTracker := tracker.Field(field.Name())
+		if !field.IsList() {
+			x.walk(val.Message(), fieldTracker)
+			return true
+		}
+		list := val.List()
+		for i := 0; i < list.Len(); i++ {
+			x.walk(list.Get(i).Message(), fieldTracker.Item(i))
+		}
+		return true
+	})
+}
+
+// Find returns the tracker for the given node, or nil if it's not part of
+// the indexed bundle.
+func (x *NodeIndex) Find(node proto.Message) *Tracker {
+	if x == nil || node == nil {
+		return nil
+	}
+	return x.nodes[node]
+}
+
+// File returns the tracker for the partial with the given virtual path, or
+// nil if it's not part of the indexed bundle.
+func (x *NodeIndex) File(name string) *Tracker {
+	if x == nil {
+		return nil
+	}
+	return x.files[name]
+}
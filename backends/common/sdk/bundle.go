@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+
+// Package sdk has the pieces that most backends need on top of a request:
+// finding types across the whole bundle, working out which of them were
+// asked for (and what those depend on), and sending back files.
+//
+// A minimal backend looks like
+//
+//   func generate(_ context.Context, req *request.Request, resp *respond.Responder) error {
+//   	bundle := sdk.Load(req)
+//   	sel, err := bundle.Requested()
+//   	if err != nil {
+//   		return err
+//   	}
+//   	out := sdk.NewOutput(resp)
+//   	for _, typ := range bundle.Closure(sel).Types() {
+//   		out.File(typ.Name.FullName+".txt", []byte(typ.Name.String()))
+//   	}
+//   	return out.Err()
+//   }
+package sdk
+
+import (
+	"sort"
+
+	"k8s.io/idl/backends/common/request"
+	"k8s.io/idl/backends/common/typegraph"
+	ir "k8s.io/idl/ckdl-ir/goir"
+	irt "k8s.io/idl/ckdl-ir/goir/types"
+)
+
+// Type is a kind or subtype from the bundle, along with where it was
+// declared.
+type Type struct {
+	Name typegraph.Name
+	// Kind is set if the type is a kind.
+	Kind *irt.Kind
+	// Subtype is set if the type is a subtype.
+	Subtype *irt.Subtype
+
+	// GroupVersion is the group-version the type was declared in.
+	GroupVersion *ir.GroupVersion
+	// File is the virtual path of the file the type was declared in.
+	File string
+}
+
+// Bundle knows about every type in a request's bundle, across all the
+// partials in it.
+type Bundle struct {
+	// Graph follows references through aliases, for backends that care
+	// about what a reference eventually points to.
+	*typegraph.Graph
+
+	types map[typegraph.Name]*Type
+	// order is every type, sorted by group-version, then in declaration
+	// order (kinds first), so that output doesn't depend on map order.
+	order []*Type
+	gvs []request.GroupVersion
+	req *request.Request
+}
+
+// Load indexes the types in the given request's bundle.
+func Load(req *request.Request) *Bundle {
+	allGVs := req.Loader.GroupVersions()
+	b := &Bundle{
+		Graph: typegraph.New(allGVs),
+		types: make(map[typegraph.Name]*Type),
+		req: req,
+	}
+	for gv := range allGVs {
+		b.gvs = append(b.gvs, gv)
+	}
+	sort.Slice(b.gvs, func(i, j int) bool {
+		if b.gvs[i].Group != b.gvs[j].Group {
+			return b.gvs[i].Group < b.gvs[j].Group
+		}
+		return b.gvs[i].Version < b.gvs[j].Version
+	})
+
+	for _, gv := range b.gvs {
+		for _, info := range allGVs[gv] {
+			for _, kind := range info.GroupVersion.Kinds {
+				b.add(&Type{
+					Name: typegraph.Name{GroupVersion: gv, FullName: kind.Name},
+					Kind: kind,
+					GroupVersion: info.GroupVersion,
+					File: info.OriginalName,
+				})
+			}
+			for _, subtype := range info.GroupVersion.Types {
+				b.add(&Type{
+					Name: typegraph.Name{GroupVersion: gv, FullName: subtype.Name},
+					Subtype: subtype,
+					GroupVersion: info.GroupVersion,
+					File: info.OriginalName,
+				})
+			}
+		}
+	}
+	return b
+}
+
+func (b *Bundle) add(typ *Type) {
+	if _, exists := b.types[typ.Name]; exists {
+		// the compiler doesn't let this happen, but don't list it twice
+		// if it does
+		return
+	}
+	b.types[typ.Name] = typ
+	b.order = append(b.order, typ)
+}
+
+// Lookup returns the type the given reference names directly (without
+// following aliases), if it's in the bundle.
+func (b *Bundle) Lookup(ref *irt.Reference) (*Type, bool) {
+	if ref == nil || ref.GroupVersion == nil {
+		return nil, false
+	}
+	return b.Named(typegraph.NameFromRef(ref))
+}
+
+// Named returns the type with the given name, if it's in the bundle.
+func (b *Bundle) Named(name typegraph.Name) (*Type, bool) {
+	typ, known := b.types[name]
+	return typ, known
+}
+
+// Types returns every type in the bundle, in a stable order.
+func (b *Bundle) Types() []*Type {
+	return b.order
+}
+
+// GroupVersions returns every group-version in the bundle, sorted.
+func (b *Bundle) GroupVersions() []request.GroupVersion {
+	return b.gvs
+}
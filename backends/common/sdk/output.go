@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package sdk
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+
+	"k8s.io/idl/backends/common/backend"
+	"k8s.io/idl/backends/common/respond"
+)
+
+// Output sends files back to the compiler, catching the mistakes that are
+// easy to make when generating lots of them: writing the same file twice,
+// or naming a file outside the output directory.  Problems are logged as
+// they happen, and Err reports whether there were any.
+type Output struct {
+	resp *respond.Responder
+	written map[string]bool
+	failed bool
+}
+
+// NewOutput returns an output that sends files with the given responder.
+func NewOutput(resp *respond.Responder) *Output {
+	return &Output{resp: resp, written: make(map[string]bool)}
+}
+
+// File sends a file, named by a slash-separated path relative to the
+// output directory.
+func (o *Output) File(name string, contents []byte) {
+	if err := o.check(name); err != nil {
+		o.resp.GeneralError(err, "unable to write file", "file", name)
+		o.failed = true
+		return
+	}
+	o.written[name] = true
+	o.resp.File(name, contents)
+}
+
+func (o *Output) check(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("file name is empty")
+	case path.IsAbs(name) || strings.Contains(name, `\`):
+		return fmt.Errorf("file name must be a relative, slash-separated path")
+	case path.Clean(name) != name:
+		return fmt.Errorf("file name isn't clean (expected %q)", path.Clean(name))
+	case name == ".." || strings.HasPrefix(name, "../"):
+		return fmt.Errorf("file would be outside the output directory")
+	case o.written[name]:
+		return fmt.Errorf("file was already written")
+	}
+	return nil
+}
+
+// Create returns a buffer for a file, which is sent when it's closed.
+func (o *Output) Create(name string) *FileBuffer {
+	return &FileBuffer{out: o, name: name}
+}
+
+// Written returns whether the named file has been sent.
+func (o *Output) Written(name string) bool {
+	return o.written[name]
+}
+
+// Err returns backend.ErrReported if any files couldn't be sent, for
+// returning from a backend.
+func (o *Output) Err() error {
+	if o.failed {
+		return backend.ErrReported
+	}
+	return nil
+}
+
+// FileBuffer is a file that's sent once it's been written.
+type FileBuffer struct {
+	bytes.Buffer
+	out *Output
+	name string
+}
+
+// Close sends the file.
+func (f *FileBuffer) Close() error {
+	f.out.File(f.name, f.Bytes())
+	return nil
+}
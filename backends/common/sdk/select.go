@@ -0,0 +1,208 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package sdk
+
+import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"k8s.io/idl/backends/common/request"
+	"k8s.io/idl/backends/common/typegraph"
+	irt "k8s.io/idl/ckdl-ir/goir/types"
+)
+
+// AllTypes is the type name that selects a whole group-version, as in
+// group/version::*.
+const AllTypes = "*"
+
+// Selection is a set of types from a bundle, like the ones a backend was
+// asked to generate for.
+type Selection struct {
+	bundle *Bundle
+	names map[typegraph.Name]bool
+}
+
+func (b *Bundle) newSelection() *Selection {
+	return &Selection{bundle: b, names: make(map[typegraph.Name]bool)}
+}
+
+// All selects every type in the bundle.
+func (b *Bundle) All() *Selection {
+	sel := b.newSelection()
+	for name := range b.types {
+		sel.names[name] = true
+	}
+	return sel
+}
+
+// Requested selects the types from the request, or every type if the
+// request didn't ask for any in particular.
+func (b *Bundle) Requested() (*Selection, error) {
+	if len(b.req.Types) == 0 {
+		return b.All(), nil
+	}
+	return b.Select(b.req.Types...)
+}
+
+// Select selects the given types.  A type named AllTypes selects every
+// type in its group-version.  It's an error to select types (or
+// group-versions) that aren't in the bundle.
+func (b *Bundle) Select(idents ...request.TypeIdent) (*Selection, error) {
+	sel := b.newSelection()
+	for _, ident := range idents {
+		gv := request.GroupVersion{Group: ident.Group, Version: ident.Version}
+		if ident.Type == AllTypes {
+			if err := sel.addGroupVersion(gv); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		name := typegraph.Name{GroupVersion: gv, FullName: ident.Type}
+		if _, known := b.types[name]; !known {
+			return nil, fmt.Errorf("unknown type %s", name)
+		}
+		sel.names[name] = true
+	}
+	return sel, nil
+}
+
+// SelectGroupVersions selects every type in the given group-versions.
+func (b *Bundle) SelectGroupVersions(gvs ...request.GroupVersion) (*Selection, error) {
+	sel := b.newSelection()
+	for _, gv := range gvs {
+		if err := sel.addGroupVersion(gv); err != nil {
+			return nil, err
+		}
+	}
+	return sel, nil
+}
+
+func (s *Selection) addGroupVersion(gv request.GroupVersion) error {
+	if _, known := s.bundle.req.Loader.GroupVersions()[gv]; !known {
+		return fmt.Errorf("unknown group-version %s", gv)
+	}
+	for _, typ := range s.bundle.order {
+		if typ.Name.GroupVersion == gv {
+			s.names[typ.Name] = true
+		}
+	}
+	return nil
+}
+
+// Has checks if the named type is selected.
+func (s *Selection) Has(name typegraph.Name) bool {
+	return s.names[name]
+}
+
+// Len returns the number of selected types.
+func (s *Selection) Len() int {
+	return len(s.names)
+}
+
+// Types returns the selected types, in the same order as Bundle.Types.
+func (s *Selection) Types() []*Type {
+	var res []*Type
+	for _, typ := range s.bundle.order {
+		if s.names[typ.Name] {
+			res = append(res, typ)
+		}
+	}
+	return res
+}
+
+// GroupVersions returns the group-versions with selected types in them,
+// sorted.
+func (s *Selection) GroupVersions() []request.GroupVersion {
+	var res []request.GroupVersion
+	for _, typ := range s.Types() {
+		if len(res) == 0 || res[len(res)-1] != typ.Name.GroupVersion {
+			res = append(res, typ.Name.GroupVersion)
+		}
+	}
+	return res
+}
+
+// Files returns the virtual paths of the files that declare the selected
+// types, sorted.
+func (s *Selection) Files() []string {
+	seen := make(map[string]bool)
+	var res []string
+	for _, typ := range s.Types() {
+		if !seen[typ.File] {
+			seen[typ.File] = true
+			res = append(res, typ.File)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Closure returns the given selection plus every type the selected types
+// refer to, directly or indirectly, that's in the bundle.
+func (b *Bundle) Closure(sel *Selection) *Selection {
+	res := b.newSelection()
+	var queue []*Type
+	for _, typ := range sel.Types() {
+		res.names[typ.Name] = true
+		queue = append(queue, typ)
+	}
+	for len(queue) > 0 {
+		typ := queue[0]
+		queue = queue[1:]
+
+		var node proto.Message = typ.Kind
+		if typ.Subtype != nil {
+			node = typ.Subtype
+		}
+		for _, ref := range References(node) {
+			dep, known := b.Lookup(ref)
+			if !known || res.names[dep.Name] {
+				continue
+			}
+			res.names[dep.Name] = true
+			queue = append(queue, dep)
+		}
+	}
+	return res
+}
+
+var referenceName = (&irt.Reference{}).ProtoReflect().Descriptor().FullName()
+
+// References returns every type reference anywhere in the given IR node
+// (e.g. in the fields of a kind, or the body of a subtype).
+func References(node proto.Message) []*irt.Reference {
+	var refs []*irt.Reference
+	var walk func(msg protoreflect.Message)
+	walk = func(msg protoreflect.Message) {
+		if msg.Descriptor().FullName() == referenceName {
+			refs = append(refs, msg.Interface().(*irt.Reference))
+			return
+		}
+		msg.Range(func(field protoreflect.FieldDescriptor, val protoreflect.Value) bool {
+			switch {
+			case field.Message() == nil:
+			case field.IsList():
+				list := val.List()
+				for i := 0; i < list.Len(); i++ {
+					walk(list.Get(i).Message())
+				}
+			case field.IsMap():
+				if field.MapValue().Message() == nil {
+					break
+				}
+				val.Map().Range(func(_ protoreflect.MapKey, item protoreflect.Value) bool {
+					walk(item.Message())
+					return true
+				})
+			default:
+				walk(val.Message())
+			}
+			return true
+		})
+	}
+	walk(node.ProtoReflect())
+	return refs
+}
@@ -0,0 +1,367 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+
+// Package smd converts types from a bundle into structured-merge-diff
+// schemata, which describe the list & map topology that server-side apply
+// needs.
+package smd
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+	"sigs.k8s.io/structured-merge-diff/v4/schema"
+
+	"k8s.io/idl/backends/common/typegraph"
+	irt "k8s.io/idl/ckdl-ir/goir/types"
+)
+
+const (
+	// ObjectMetaName is the name that ObjectMeta has in the generated
+	// schema, matching the name it has in Kubernetes' own schemata.
+	ObjectMetaName = "io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"
+
+	untypedAtomic  = "__untyped_atomic_"
+	untypedDeduced = "__untyped_deduced_"
+)
+
+// Namer returns the name to use in the schema for the given kind or
+// subtype.  Names must be unique across the whole schema.
+type Namer func(typegraph.Name) string
+
+// Builder collects the schema types for kinds & subtypes in a type graph,
+// along with everything they reference.
+type Builder struct {
+	types *typegraph.Graph
+	name  Namer
+
+	defs map[string]schema.TypeDef
+}
+
+func NewBuilder(types *typegraph.Graph, namer Namer) *Builder {
+	return &Builder{
+		types: types,
+		name:  namer,
+		defs:  make(map[string]schema.TypeDef),
+	}
+}
+
+// Add adds the given kind or subtype (and anything it references) to the
+// schema, returning its name in the schema.  Reference aliases are followed
+// to the type they eventually point to, since the schema has no notion of
+// them.
+func (b *Builder) Add(name typegraph.Name) (string, error) {
+	if subtype, isSubtype := b.types.Subtypes[name]; isSubtype {
+		if alias, isAlias := subtype.Type.(*irt.Subtype_ReferenceAlias); isAlias {
+			return b.addRef(alias.ReferenceAlias)
+		}
+	}
+
+	schemaName := b.name(name)
+	if _, exists := b.defs[schemaName]; exists {
+		return schemaName, nil
+	}
+	// reserve the name first, since types may be recursive
+	b.defs[schemaName] = schema.TypeDef{Name: schemaName}
+
+	var atom schema.Atom
+	var err error
+	if kind, isKind := b.types.Kinds[name]; isKind {
+		atom, err = b.kindAtom(kind)
+	} else if subtype, isSubtype := b.types.Subtypes[name]; isSubtype {
+		atom, err = b.subtypeAtom(subtype)
+	} else {
+		err = fmt.Errorf("unknown type %s", name)
+	}
+	if err != nil {
+		delete(b.defs, schemaName)
+		return "", err
+	}
+	b.defs[schemaName] = schema.TypeDef{Name: schemaName, Atom: atom}
+	return schemaName, nil
+}
+
+// Schema returns the types added so far (plus the ones they need from
+// apimachinery), sorted by name.
+func (b *Builder) Schema() *schema.Schema {
+	defs := make(map[string]schema.TypeDef, len(b.defs))
+	for name, def := range b.defs {
+		defs[name] = def
+	}
+	for _, def := range builtinDefs() {
+		defs[def.Name] = def
+	}
+
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	res := &schema.Schema{Types: make([]schema.TypeDef, len(names))}
+	for i, name := range names {
+		res.Types[i] = defs[name]
+	}
+	return res
+}
+
+// YAML serializes the schema in the form that typed.NewParser expects.
+func (b *Builder) YAML() ([]byte, error) {
+	return yaml.Marshal(b.Schema())
+}
+
+func (b *Builder) kindAtom(kind *irt.Kind) (schema.Atom, error) {
+	fields := []schema.StructField{
+		{Name: "apiVersion", Type: scalar(schema.String)},
+		{Name: "kind", Type: scalar(schema.String)},
+	}
+	if kind.Object {
+		fields = append(fields, schema.StructField{
+			Name:    "metadata",
+			Type:    named(ObjectMetaName),
+			Default: map[string]interface{}{},
+		})
+	}
+	rest, err := b.fields(kind.Fields)
+	if err != nil {
+		return schema.Atom{}, err
+	}
+	return schema.Atom{Map: &schema.Map{Fields: append(fields, rest...)}}, nil
+}
+
+func (b *Builder) subtypeAtom(subtype *irt.Subtype) (schema.Atom, error) {
+	switch body := subtype.Type.(type) {
+	case *irt.Subtype_Struct:
+		fields, err := b.fields(body.Struct.Fields)
+		if err != nil {
+			return schema.Atom{}, err
+		}
+		res := &schema.Map{Fields: fields}
+		if body.Struct.PreserveUnknownFields {
+			res.ElementType = named(untypedDeduced)
+		}
+		return schema.Atom{Map: res}, nil
+	case *irt.Subtype_Union:
+		return b.unionAtom(body.Union)
+	case *irt.Subtype_Enum:
+		return scalar(schema.String).Inlined, nil
+	case *irt.Subtype_PrimitiveAlias:
+		return primRef(body.PrimitiveAlias).Inlined, nil
+	case *irt.Subtype_Set:
+		typ, err := b.setRef(body.Set)
+		return typ.Inlined, err
+	case *irt.Subtype_List:
+		typ, err := b.listRef(body.List)
+		return typ.Inlined, err
+	case *irt.Subtype_PrimitiveMap:
+		typ, err := b.primMapRef(body.PrimitiveMap)
+		return typ.Inlined, err
+	case *irt.Subtype_ListMap:
+		typ, err := b.listMapRef(body.ListMap)
+		return typ.Inlined, err
+	default:
+		return schema.Atom{}, fmt.Errorf("unknown subtype type %T", body)
+	}
+}
+
+func (b *Builder) unionAtom(union *irt.Union) (schema.Atom, error) {
+	var fields []schema.StructField
+	schemaUnion := schema.Union{}
+	if !union.Untagged {
+		tag := union.Tag
+		schemaUnion.Discriminator = &tag
+		fields = append(fields, schema.StructField{Name: tag, Type: scalar(schema.String)})
+	}
+	variants, err := b.fields(union.Variants)
+	if err != nil {
+		return schema.Atom{}, err
+	}
+	for _, variant := range union.Variants {
+		// the tag values are the serialized field names (see the oneOf in
+		// the CRD schema)
+		schemaUnion.Fields = append(schemaUnion.Fields, schema.UnionField{
+			FieldName:          variant.Name,
+			DiscriminatorValue: variant.Name,
+		})
+	}
+	return schema.Atom{Map: &schema.Map{
+		Fields: append(fields, variants...),
+		Unions: []schema.Union{schemaUnion},
+	}}, nil
+}
+
+// fields converts struct fields, flattening embedded fields into their
+// parent, since that's what they look like when serialized.
+func (b *Builder) fields(fields []*irt.Field) ([]schema.StructField, error) {
+	var res []schema.StructField
+	for _, field := range fields {
+		if field.Embedded {
+			embedded, err := b.embeddedFields(field)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, embedded...)
+			continue
+		}
+
+		typ, err := b.fieldRef(field)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", field.Name, err)
+		}
+		schemaField := schema.StructField{Name: field.Name, Type: typ}
+		if field.Default != nil {
+			schemaField.Default = field.Default.AsInterface()
+		}
+		res = append(res, schemaField)
+	}
+	return res, nil
+}
+
+func (b *Builder) embeddedFields(field *irt.Field) ([]schema.StructField, error) {
+	named, isNamed := field.Type.(*irt.Field_NamedType)
+	if !isNamed {
+		return nil, fmt.Errorf("embedded field must refer to a struct, not a %T", field.Type)
+	}
+	term, known := b.types.Resolve(named.NamedType)
+	if !known {
+		return nil, fmt.Errorf("unknown embedded type %s", typegraph.NameFromRef(named.NamedType))
+	}
+	switch term := term.(type) {
+	case typegraph.TerminalStruct:
+		return b.fields(term.Struct.Fields)
+	case typegraph.TerminalKind:
+		return b.fields(term.Kind.Fields)
+	default:
+		return nil, fmt.Errorf("embedded field must refer to a struct, not %s", typegraph.NameFromRef(named.NamedType))
+	}
+}
+
+func (b *Builder) fieldRef(field *irt.Field) (schema.TypeRef, error) {
+	switch typ := field.Type.(type) {
+	case *irt.Field_Primitive:
+		return primRef(typ.Primitive), nil
+	case *irt.Field_NamedType:
+		return b.namedRef(typ.NamedType)
+	case *irt.Field_Set:
+		return b.setRef(typ.Set)
+	case *irt.Field_List:
+		return b.listRef(typ.List)
+	case *irt.Field_PrimitiveMap:
+		return b.primMapRef(typ.PrimitiveMap)
+	case *irt.Field_ListMap:
+		return b.listMapRef(typ.ListMap)
+	default:
+		return schema.TypeRef{}, fmt.Errorf("unknown field type %T", typ)
+	}
+}
+
+// addRef adds the type for a reference.  References that leave the bundle
+// can't be described, so they're left untyped.
+func (b *Builder) addRef(ref *irt.Reference) (string, error) {
+	if !b.types.Known(ref) {
+		return untypedDeduced, nil
+	}
+	return b.Add(typegraph.NameFromRef(ref))
+}
+
+func (b *Builder) namedRef(ref *irt.Reference) (schema.TypeRef, error) {
+	name, err := b.addRef(ref)
+	if err != nil {
+		return schema.TypeRef{}, err
+	}
+	return named(name), nil
+}
+
+func (b *Builder) setRef(set *irt.Set) (schema.TypeRef, error) {
+	var items schema.TypeRef
+	switch typ := set.Items.(type) {
+	case *irt.Set_Primitive:
+		items = primRef(typ.Primitive)
+	case *irt.Set_Reference:
+		var err error
+		if items, err = b.namedRef(typ.Reference); err != nil {
+			return schema.TypeRef{}, err
+		}
+	default:
+		return schema.TypeRef{}, fmt.Errorf("unknown set items type %T", typ)
+	}
+	return listOf(items, schema.Associative), nil
+}
+
+func (b *Builder) listRef(list *irt.List) (schema.TypeRef, error) {
+	var items schema.TypeRef
+	switch typ := list.Items.(type) {
+	case *irt.List_Primitive:
+		items = primRef(typ.Primitive)
+	case *irt.List_Reference:
+		var err error
+		if items, err = b.namedRef(typ.Reference); err != nil {
+			return schema.TypeRef{}, err
+		}
+	default:
+		return schema.TypeRef{}, fmt.Errorf("unknown list items type %T", typ)
+	}
+	return listOf(items, schema.Atomic), nil
+}
+
+func (b *Builder) listMapRef(listMap *irt.ListMap) (schema.TypeRef, error) {
+	items, err := b.namedRef(listMap.Items)
+	if err != nil {
+		return schema.TypeRef{}, err
+	}
+	res := listOf(items, schema.Associative)
+	res.Inlined.List.Keys = append([]string(nil), listMap.KeyField...)
+	return res, nil
+}
+
+func (b *Builder) primMapRef(primMap *irt.PrimitiveMap) (schema.TypeRef, error) {
+	// keys are always strings when serialized, so only the values matter
+	var values schema.TypeRef
+	switch typ := primMap.Value.(type) {
+	case *irt.PrimitiveMap_PrimitiveValue:
+		values = primRef(typ.PrimitiveValue)
+	case *irt.PrimitiveMap_ReferenceValue:
+		var err error
+		if values, err = b.namedRef(typ.ReferenceValue); err != nil {
+			return schema.TypeRef{}, err
+		}
+	case *irt.PrimitiveMap_SimpleListValue:
+		var err error
+		if values, err = b.listRef(typ.SimpleListValue); err != nil {
+			return schema.TypeRef{}, err
+		}
+	default:
+		return schema.TypeRef{}, fmt.Errorf("unknown simple-map value type %T", typ)
+	}
+	return schema.TypeRef{Inlined: schema.Atom{Map: &schema.Map{ElementType: values}}}, nil
+}
+
+func primRef(prim *irt.Primitive) schema.TypeRef {
+	switch prim.Type {
+	case irt.Primitive_STRING, irt.Primitive_BYTES, irt.Primitive_DURATION:
+		return scalar(schema.String)
+	case irt.Primitive_LEGACYINT32, irt.Primitive_INT64, irt.Primitive_LEGACYFLOAT64:
+		return scalar(schema.Numeric)
+	case irt.Primitive_BOOL:
+		return scalar(schema.Boolean)
+	default:
+		// time, quantity, & int-or-string all have custom serialization
+		return named(untypedAtomic)
+	}
+}
+
+func scalar(typ schema.Scalar) schema.TypeRef {
+	return schema.TypeRef{Inlined: schema.Atom{Scalar: &typ}}
+}
+
+func named(name string) schema.TypeRef {
+	return schema.TypeRef{NamedType: &name}
+}
+
+func listOf(items schema.TypeRef, rel schema.ElementRelationship) schema.TypeRef {
+	return schema.TypeRef{Inlined: schema.Atom{List: &schema.List{
+		ElementType:         items,
+		ElementRelationship: rel,
+	}}}
+}
@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+
+// Package typegraph knows about every type in a bundle, so that backends
+// can figure out what a reference eventually points to (e.g. to decide how
+// to represent a field), even across group-versions.
+package typegraph
+
+import (
+	"fmt"
+
+	pany "github.com/golang/protobuf/ptypes/any"
+
+	"k8s.io/idl/backends/common/request"
+	irt "k8s.io/idl/ckdl-ir/goir/types"
+)
+
+// Name identifies a kind or subtype in the bundle.
+type Name struct {
+	request.GroupVersion
+	FullName string
+}
+
+func (n Name) String() string {
+	return fmt.Sprintf("%s/%s::%s", n.Group, n.Version, n.FullName)
+}
+
+// Ref returns a reference to the named type.
+func (n Name) Ref() *irt.Reference {
+	return &irt.Reference{
+		GroupVersion: &irt.GroupVersionRef{Group: n.Group, Version: n.Version},
+		Name:         n.FullName,
+	}
+}
+
+func NameFromRef(ref *irt.Reference) Name {
+	return Name{
+		GroupVersion: request.GroupVersion{Group: ref.GroupVersion.Group, Version: ref.GroupVersion.Version},
+		FullName:     ref.Name,
+	}
+}
+
+// NB: like the typechecker in kdlc, we split subtypes out into wrappers vs
+// struct/union/enum, since most backends treat all the wrappers the same.
+
+// Terminal is what a reference eventually points to, once reference
+// aliases have been followed.
+type Terminal interface{ isTerm() }
+type TerminalWrapper struct {
+	Wrapper *irt.Subtype
+}
+
+func (TerminalWrapper) isTerm() {}
+
+type TerminalStruct struct {
+	Struct *irt.Struct
+}
+
+func (TerminalStruct) isTerm() {}
+
+type TerminalUnion struct {
+	Union *irt.Union
+}
+
+func (TerminalUnion) isTerm() {}
+
+type TerminalEnum struct {
+	Enum *irt.Enum
+}
+
+func (TerminalEnum) isTerm() {}
+
+type TerminalKind struct {
+	Kind *irt.Kind
+}
+
+func (TerminalKind) isTerm() {}
+
+// Graph holds all the kinds and subtypes in a bundle, including the ones
+// from dependencies.
+type Graph struct {
+	Kinds    map[Name]*irt.Kind
+	Subtypes map[Name]*irt.Subtype
+}
+
+// New builds a graph from the group-versions in a bundle (see
+// request.Loader.GroupVersions).
+func New(gvs map[request.GroupVersion][]request.GroupVersionInfo) *Graph {
+	g := &Graph{
+		Kinds:    make(map[Name]*irt.Kind),
+		Subtypes: make(map[Name]*irt.Subtype),
+	}
+	for gv, infos := range gvs {
+		for _, info := range infos {
+			for _, kind := range info.GroupVersion.Kinds {
+				g.Kinds[Name{GroupVersion: gv, FullName: kind.Name}] = kind
+			}
+			for _, subtype := range info.GroupVersion.Types {
+				g.Subtypes[Name{GroupVersion: gv, FullName: subtype.Name}] = subtype
+			}
+		}
+	}
+	return g
+}
+
+// Known checks if the given reference points to a type in the bundle.
+func (g *Graph) Known(ref *irt.Reference) bool {
+	name := NameFromRef(ref)
+	_, isKind := g.Kinds[name]
+	_, isSubtype := g.Subtypes[name]
+	return isKind || isSubtype
+}
+
+// Attributes returns the markers on the type the given reference names
+// directly (without following aliases), or nil if it's not in the bundle.
+func (g *Graph) Attributes(ref *irt.Reference) []*pany.Any {
+	name := NameFromRef(ref)
+	if kind, isKind := g.Kinds[name]; isKind {
+		return kind.Attributes
+	}
+	if subtype, isSubtype := g.Subtypes[name]; isSubtype {
+		return subtype.Attributes
+	}
+	return nil
+}
+
+// Resolve follows the given reference (through any reference aliases) to
+// the terminal type it eventually points to.  It returns false if the
+// reference leads outside the bundle, or loops back on itself.
+func (g *Graph) Resolve(ref *irt.Reference) (Terminal, bool) {
+	_, term, known := g.Follow(ref)
+	return term, known
+}
+
+// Follow is like Resolve, but also returns the name of the terminal type,
+// for backends that refer to it instead of to the alias.
+func (g *Graph) Follow(ref *irt.Reference) (Name, Terminal, bool) {
+	seen := make(map[Name]bool)
+	for {
+		name := NameFromRef(ref)
+		if seen[name] {
+			return Name{}, nil, false
+		}
+		seen[name] = true
+
+		if kind, isKind := g.Kinds[name]; isKind {
+			return name, TerminalKind{kind}, true
+		}
+		subtype, isSubtype := g.Subtypes[name]
+		if !isSubtype {
+			return Name{}, nil, false
+		}
+		switch body := subtype.Type.(type) {
+		case *irt.Subtype_ReferenceAlias:
+			ref = body.ReferenceAlias
+		case *irt.Subtype_Struct:
+			return name, TerminalStruct{body.Struct}, true
+		case *irt.Subtype_Union:
+			return name, TerminalUnion{body.Union}, true
+		case *irt.Subtype_Enum:
+			return name, TerminalEnum{body.Enum}, true
+		default:
+			return name, TerminalWrapper{subtype}, true
+		}
+	}
+}
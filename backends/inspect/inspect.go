@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+
+// Package inspect is a backend that logs each partial in the bundle (or
+// just the requested types) as YAML, for debugging the compiler.
+package inspect
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/idl/backends/common/backend"
+	"k8s.io/idl/backends/common/request"
+	"k8s.io/idl/backends/common/respond"
+	"k8s.io/idl/backends/common/sdk"
+)
+
+// Backend is the inspect backend.
+var Backend backend.Backend = backend.Func(generate)
+
+func generate(_ context.Context, req *request.Request, resp *respond.Responder) error {
+	if err := req.Options.CheckKnown(); err != nil {
+		return err
+	}
+	resp.Debug("beginning")
+
+	if len(req.Types) != 0 {
+		return inspectTypes(req, resp)
+	}
+	for path, partial := range req.Loader.Partials() {
+		// TODO(directxman12): also allow writing to disk
+		asYAML, err := yaml.Marshal(partial)
+		if err != nil {
+			resp.GeneralError(err, "unable to convert partial to YAML", "path", path)
+			continue
+		}
+		resp.GeneralInfo("partial", "path", path, "contents", "---\n"+string(asYAML))
+	}
+	return nil
+}
+
+// inspectTypes logs just the requested types, instead of whole partials.
+func inspectTypes(req *request.Request, resp *respond.Responder) error {
+	sel, err := sdk.Load(req).Requested()
+	if err != nil {
+		return err
+	}
+	for _, typ := range sel.Types() {
+		var node proto.Message = typ.Kind
+		if typ.Subtype != nil {
+			node = typ.Subtype
+		}
+		asYAML, err := yaml.Marshal(node)
+		if err != nil {
+			resp.GeneralError(err, "unable to convert type to YAML", "type", typ.Name.String())
+			continue
+		}
+		resp.InfoAt(resp.Node(node), "type", "type", typ.Name.String(), "contents", "---\n"+string(asYAML))
+	}
+	return nil
+}
@@ -1,18 +1,26 @@
 module k8s.io/idl/backends/markerproto
 
-go 1.15
+go 1.22.0
 
 replace (
 	k8s.io/idl/backends/common => ../common
+	k8s.io/idl/backends/inspect => ../inspect
+	k8s.io/idl/backends/markerproto => ./
+	k8s.io/idl/backends/tocrd => ../tocrd
+	k8s.io/idl/backends/tokgo => ../tokgo
+	k8s.io/idl/backends/tosmd => ../tosmd
 	k8s.io/idl/ckdl-ir/goir => ../../ckdl-ir/goir
 	k8s.io/idl/kdlc => ../../kdlc
 )
 
 require (
-	github.com/golang/protobuf v1.4.3
-	google.golang.org/protobuf v1.25.0
+	google.golang.org/protobuf v1.30.0
 	k8s.io/idl/backends/common v0.0.0-00010101000000-000000000000
-	k8s.io/idl/ckdl-ir/goir v0.0.0-00010101000000-000000000000
 	k8s.io/idl/kdlc v0.0.0-00010101000000-000000000000
-	sigs.k8s.io/yaml v1.2.0
+)
+
+require (
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/google/go-cmp v0.6.0 // indirect
+	k8s.io/idl/ckdl-ir/goir v0.0.0-00010101000000-000000000000 // indirect
 )
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+
+// Package markerproto is a backend that turns marker definitions into
+// proto descriptor sets, so that other backends can generate code for
+// parsing them.
+package markerproto
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"k8s.io/idl/backends/common/backend"
+	"k8s.io/idl/backends/common/request"
+	"k8s.io/idl/backends/common/respond"
+	"k8s.io/idl/backends/common/sdk"
+	"k8s.io/idl/kdlc/mdesc"
+)
+
+// Backend is the markerproto backend.
+var Backend backend.Backend = backend.Func(generate)
+
+func generate(ctx context.Context, req *request.Request, resp *respond.Responder) error {
+	if err := req.Options.CheckKnown(); err != nil {
+		return err
+	}
+	resp.Debug("beginning")
+
+	// with specific types, only generate for the files they're in
+	var onlyFiles map[string]bool
+	if len(req.Types) != 0 {
+		sel, err := sdk.Load(req).Requested()
+		if err != nil {
+			return err
+		}
+		onlyFiles = make(map[string]bool)
+		for _, file := range sel.Files() {
+			onlyFiles[file] = true
+		}
+	}
+
+	for srcPath, partial := range req.Loader.Partials() {
+		if onlyFiles != nil && !onlyFiles[srcPath] {
+			continue
+		}
+		// TODO: share this logic between the kdlc and this?
+		for _, set := range partial.MarkerSets {
+			resp.Debug("processing package", "package", set.Package)
+			res := mdesc.MakeDescriptor(ctx, srcPath, set)
+			if len(res.DescriptorNames) != len(set.Markers) {
+				// TODO: get this from trace.HadError
+				resp.GeneralError(nil, "unable to convert markers to proto definitions", "package", set.Package)
+				continue
+			}
+
+			// TODO: figure out how to get this as proto source instead of a descriptor
+			contents, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{res.File}})
+			if err != nil {
+				resp.GeneralError(err, "unable to marshal proto descriptor", "package", set.Package)
+				continue
+			}
+			descPath := srcPath+".desc"
+			resp.File(descPath, contents)
+		}
+	}
+	return nil
+}
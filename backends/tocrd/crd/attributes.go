@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package crd
+
+import (
+	"fmt"
+
+	pany "github.com/golang/protobuf/ptypes/any"
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	crdmarkers "k8s.io/idl/backends/tocrd/crd/markers"
+)
+
+//go:generate kdlc -i ../.. -d ../.. -o markerproto tocrd/markers.kdl
+//go:generate protoc --descriptor_set_in=../markers.kdl.desc --go_out=. --go_opt=Mtocrd/markers.kdl=k8s.io/idl/backends/tocrd/crd;crd --go_opt=module=k8s.io/idl/backends/tocrd/crd tocrd/markers.kdl
+
+// specMarkersFor decodes the CRD-level markers (from markers.kdl) attached
+// to a kind into SpecMarkers.  Markers that don't affect the CRD spec are
+// skipped.
+func specMarkersFor(attrs []*pany.Any) ([]SpecMarker, error) {
+	var res []SpecMarker
+	for _, attr := range attrs {
+		switch {
+		case attr.MessageIs(&StorageVersion{}):
+			res = append(res, crdmarkers.StorageVersion{})
+		case attr.MessageIs(&UnservedVersion{}):
+			res = append(res, crdmarkers.UnservedVersion{})
+		case attr.MessageIs(&DeprecatedVersion{}):
+			var deprecated DeprecatedVersion
+			if err := attr.UnmarshalTo(&deprecated); err != nil {
+				return nil, fmt.Errorf("unable to decode deprecated-version marker: %w", err)
+			}
+			res = append(res, crdmarkers.DeprecatedVersion{Warning: deprecated.Warning})
+		case attr.MessageIs(&SubresourceStatus{}):
+			res = append(res, crdmarkers.SubresourceStatus{})
+		case attr.MessageIs(&SubresourceScale{}):
+			var scale SubresourceScale
+			if err := attr.UnmarshalTo(&scale); err != nil {
+				return nil, fmt.Errorf("unable to decode subresource-scale marker: %w", err)
+			}
+			// field paths are stored sans leading dot, but CRDs want JSONPath-ish paths
+			marker := crdmarkers.SubresourceScale{
+				SpecPath:   "." + scale.SpecPath,
+				StatusPath: "." + scale.StatusPath,
+			}
+			if scale.SelectorPath != "" {
+				selectorPath := "." + scale.SelectorPath
+				marker.SelectorPath = &selectorPath
+			}
+			res = append(res, marker)
+		case attr.MessageIs(&PrintColumn{}):
+			var column PrintColumn
+			if err := attr.UnmarshalTo(&column); err != nil {
+				return nil, fmt.Errorf("unable to decode print-column marker: %w", err)
+			}
+			res = append(res, crdmarkers.PrintColumn{
+				Name:        column.Name,
+				Type:        column.Type,
+				JSONPath:    column.JsonPath,
+				Description: column.Description,
+				Format:      column.Format,
+				Priority:    column.Priority,
+			})
+		case attr.MessageIs(&Resource{}):
+			var resource Resource
+			if err := attr.UnmarshalTo(&resource); err != nil {
+				return nil, fmt.Errorf("unable to decode resource marker: %w", err)
+			}
+			switch apiext.ResourceScope(resource.Scope) {
+			case "", apiext.NamespaceScoped, apiext.ClusterScoped:
+			default:
+				return nil, fmt.Errorf("resource scope must be %q or %q, not %q", apiext.NamespaceScoped, apiext.ClusterScoped, resource.Scope)
+			}
+			res = append(res, crdmarkers.Resource{
+				Path:       resource.Path,
+				ShortName:  resource.ShortNames,
+				Categories: resource.Categories,
+				Singular:   resource.Singular,
+				Scope:      resource.Scope,
+			})
+		}
+	}
+	return res, nil
+}
+
+// versionOrderFor returns the explicit version-order for a kind, if any.
+func versionOrderFor(attrs []*pany.Any) (order int32, present bool, err error) {
+	for _, attr := range attrs {
+		if !attr.MessageIs(&VersionOrder{}) {
+			continue
+		}
+		if present {
+			return 0, false, fmt.Errorf("version-order may only be specified once")
+		}
+		var versionOrder VersionOrder
+		if err := attr.UnmarshalTo(&versionOrder); err != nil {
+			return 0, false, fmt.Errorf("unable to decode version-order marker: %w", err)
+		}
+		order, present = versionOrder.Order, true
+	}
+	return order, present, nil
+}
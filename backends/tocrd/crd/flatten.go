@@ -126,7 +126,12 @@ func flattenAllOfInto(dst *apiext.JSONSchemaProps, src apiext.JSONSchemaProps, e
 				errRec.AddError(fmt.Errorf("conflicting types in allOf branches in schema: %s vs %s", dstInt, srcInt))
 			}
 			// keep the destination value, for now
-		// TODO(directxman12): Default -- use field?
+		case "Default":
+			// the field's default (which comes later) wins over the type's
+			dstField.Set(srcField)
+		case "XValidations":
+			// rules all apply, so just merge them
+			dstField.Set(reflect.AppendSlice(dstField, srcField))
 		// TODO(directxman12):
 		// - Dependencies: if field x is present, then either schema validates or all props are present
 		// - AdditionalItems: like AdditionalProperties
@@ -260,7 +265,7 @@ func (f *Flattener) FlattenType(typ TypeIdent) *apiext.JSONSchemaProps {
 	}
 	baseSchema, err := f.loadUnflattenedSchema(typ)
 	if err != nil {
-		panic(err)
+		f.Parser.AddError(err)
 		return nil
 	}
 	resSchema := f.FlattenSchema(*baseSchema)
@@ -361,7 +366,7 @@ func (f *flattenVisitor) Visit(baseSchema *apiext.JSONSchemaProps) SchemaVisitor
 		// resolve this ref
 		refIdent, err := f.LookupReference(*baseSchema.Ref)
 		if err != nil {
-			panic(err)
+			f.Parser.AddError(err)
 			return nil
 		}
 
@@ -378,7 +383,7 @@ func (f *flattenVisitor) Visit(baseSchema *apiext.JSONSchemaProps) SchemaVisitor
 		// ...otherwise, we need to flatten
 		refSchema, err := f.loadUnflattenedSchema(refIdent)
 		if err != nil {
-			panic(err)
+			f.Parser.AddError(err)
 			return nil
 		}
 		refSchema = refSchema.DeepCopy()
@@ -0,0 +1,662 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.13.0
+// source: tocrd/markers.kdl
+
+package crd
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type StorageVersion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StorageVersion) Reset() {
+	*x = StorageVersion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tocrd_markers_kdl_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageVersion) ProtoMessage() {}
+
+func (x *StorageVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_tocrd_markers_kdl_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageVersion.ProtoReflect.Descriptor instead.
+func (*StorageVersion) Descriptor() ([]byte, []int) {
+	return file_tocrd_markers_kdl_rawDescGZIP(), []int{0}
+}
+
+type UnservedVersion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UnservedVersion) Reset() {
+	*x = UnservedVersion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tocrd_markers_kdl_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnservedVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnservedVersion) ProtoMessage() {}
+
+func (x *UnservedVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_tocrd_markers_kdl_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnservedVersion.ProtoReflect.Descriptor instead.
+func (*UnservedVersion) Descriptor() ([]byte, []int) {
+	return file_tocrd_markers_kdl_rawDescGZIP(), []int{1}
+}
+
+type DeprecatedVersion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Warning string `protobuf:"bytes,1,opt,name=warning,proto3" json:"warning,omitempty"`
+}
+
+func (x *DeprecatedVersion) Reset() {
+	*x = DeprecatedVersion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tocrd_markers_kdl_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeprecatedVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeprecatedVersion) ProtoMessage() {}
+
+func (x *DeprecatedVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_tocrd_markers_kdl_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeprecatedVersion.ProtoReflect.Descriptor instead.
+func (*DeprecatedVersion) Descriptor() ([]byte, []int) {
+	return file_tocrd_markers_kdl_rawDescGZIP(), []int{2}
+}
+
+func (x *DeprecatedVersion) GetWarning() string {
+	if x != nil {
+		return x.Warning
+	}
+	return ""
+}
+
+type VersionOrder struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Order int32 `protobuf:"varint,1,opt,name=order,proto3" json:"order,omitempty"`
+}
+
+func (x *VersionOrder) Reset() {
+	*x = VersionOrder{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tocrd_markers_kdl_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VersionOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionOrder) ProtoMessage() {}
+
+func (x *VersionOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_tocrd_markers_kdl_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionOrder.ProtoReflect.Descriptor instead.
+func (*VersionOrder) Descriptor() ([]byte, []int) {
+	return file_tocrd_markers_kdl_rawDescGZIP(), []int{3}
+}
+
+func (x *VersionOrder) GetOrder() int32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+type SubresourceStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SubresourceStatus) Reset() {
+	*x = SubresourceStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tocrd_markers_kdl_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubresourceStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubresourceStatus) ProtoMessage() {}
+
+func (x *SubresourceStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_tocrd_markers_kdl_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubresourceStatus.ProtoReflect.Descriptor instead.
+func (*SubresourceStatus) Descriptor() ([]byte, []int) {
+	return file_tocrd_markers_kdl_rawDescGZIP(), []int{4}
+}
+
+type SubresourceScale struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SpecPath     string `protobuf:"bytes,1,opt,name=spec_path,json=specPath,proto3" json:"spec_path,omitempty"`
+	StatusPath   string `protobuf:"bytes,2,opt,name=status_path,json=statusPath,proto3" json:"status_path,omitempty"`
+	SelectorPath string `protobuf:"bytes,3,opt,name=selector_path,json=selectorPath,proto3" json:"selector_path,omitempty"`
+}
+
+func (x *SubresourceScale) Reset() {
+	*x = SubresourceScale{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tocrd_markers_kdl_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubresourceScale) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubresourceScale) ProtoMessage() {}
+
+func (x *SubresourceScale) ProtoReflect() protoreflect.Message {
+	mi := &file_tocrd_markers_kdl_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubresourceScale.ProtoReflect.Descriptor instead.
+func (*SubresourceScale) Descriptor() ([]byte, []int) {
+	return file_tocrd_markers_kdl_rawDescGZIP(), []int{5}
+}
+
+func (x *SubresourceScale) GetSpecPath() string {
+	if x != nil {
+		return x.SpecPath
+	}
+	return ""
+}
+
+func (x *SubresourceScale) GetStatusPath() string {
+	if x != nil {
+		return x.StatusPath
+	}
+	return ""
+}
+
+func (x *SubresourceScale) GetSelectorPath() string {
+	if x != nil {
+		return x.SelectorPath
+	}
+	return ""
+}
+
+type PrintColumn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type        string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	JsonPath    string `protobuf:"bytes,3,opt,name=json_path,json=jsonPath,proto3" json:"json_path,omitempty"`
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Format      string `protobuf:"bytes,5,opt,name=format,proto3" json:"format,omitempty"`
+	Priority    int32  `protobuf:"varint,6,opt,name=priority,proto3" json:"priority,omitempty"`
+}
+
+func (x *PrintColumn) Reset() {
+	*x = PrintColumn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tocrd_markers_kdl_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrintColumn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrintColumn) ProtoMessage() {}
+
+func (x *PrintColumn) ProtoReflect() protoreflect.Message {
+	mi := &file_tocrd_markers_kdl_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrintColumn.ProtoReflect.Descriptor instead.
+func (*PrintColumn) Descriptor() ([]byte, []int) {
+	return file_tocrd_markers_kdl_rawDescGZIP(), []int{6}
+}
+
+func (x *PrintColumn) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PrintColumn) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PrintColumn) GetJsonPath() string {
+	if x != nil {
+		return x.JsonPath
+	}
+	return ""
+}
+
+func (x *PrintColumn) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PrintColumn) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *PrintColumn) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type Resource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path       string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	ShortNames []string `protobuf:"bytes,2,rep,name=short_names,json=shortNames,proto3" json:"short_names,omitempty"`
+	Categories []string `protobuf:"bytes,3,rep,name=categories,proto3" json:"categories,omitempty"`
+	Singular   string   `protobuf:"bytes,4,opt,name=singular,proto3" json:"singular,omitempty"`
+	Scope      string   `protobuf:"bytes,5,opt,name=scope,proto3" json:"scope,omitempty"`
+}
+
+func (x *Resource) Reset() {
+	*x = Resource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tocrd_markers_kdl_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Resource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resource) ProtoMessage() {}
+
+func (x *Resource) ProtoReflect() protoreflect.Message {
+	mi := &file_tocrd_markers_kdl_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resource.ProtoReflect.Descriptor instead.
+func (*Resource) Descriptor() ([]byte, []int) {
+	return file_tocrd_markers_kdl_rawDescGZIP(), []int{7}
+}
+
+func (x *Resource) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Resource) GetShortNames() []string {
+	if x != nil {
+		return x.ShortNames
+	}
+	return nil
+}
+
+func (x *Resource) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *Resource) GetSingular() string {
+	if x != nil {
+		return x.Singular
+	}
+	return ""
+}
+
+func (x *Resource) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+var File_tocrd_markers_kdl protoreflect.FileDescriptor
+
+var file_tocrd_markers_kdl_rawDesc = []byte{
+	0x0a, 0x11, 0x74, 0x6f, 0x63, 0x72, 0x64, 0x2f, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x2e,
+	0x6b, 0x64, 0x6c, 0x12, 0x12, 0x6b, 0x62, 0x2e, 0x69, 0x72, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65,
+	0x6e, 0x64, 0x73, 0x2e, 0x63, 0x72, 0x64, 0x22, 0x10, 0x0a, 0x0e, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x11, 0x0a, 0x0f, 0x55, 0x6e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x2d, 0x0a, 0x11,
+	0x44, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x24, 0x0a, 0x0c, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x22, 0x13, 0x0a, 0x11, 0x53, 0x75, 0x62, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x75, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x70,
+	0x65, 0x63, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x70, 0x65, 0x63, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x50, 0x61, 0x74, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x50, 0x61, 0x74, 0x68, 0x22, 0xa8, 0x01,
+	0x0a, 0x0b, 0x50, 0x72, 0x69, 0x6e, 0x74, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6a, 0x73, 0x6f, 0x6e, 0x5f, 0x70, 0x61,
+	0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6a, 0x73, 0x6f, 0x6e, 0x50, 0x61,
+	0x74, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x91, 0x01, 0x0a, 0x08, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x68, 0x6f,
+	0x72, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a,
+	0x73, 0x68, 0x6f, 0x72, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a,
+	0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x69,
+	0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x69,
+	0x6e, 0x67, 0x75, 0x6c, 0x61, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_tocrd_markers_kdl_rawDescOnce sync.Once
+	file_tocrd_markers_kdl_rawDescData = file_tocrd_markers_kdl_rawDesc
+)
+
+func file_tocrd_markers_kdl_rawDescGZIP() []byte {
+	file_tocrd_markers_kdl_rawDescOnce.Do(func() {
+		file_tocrd_markers_kdl_rawDescData = protoimpl.X.CompressGZIP(file_tocrd_markers_kdl_rawDescData)
+	})
+	return file_tocrd_markers_kdl_rawDescData
+}
+
+var file_tocrd_markers_kdl_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_tocrd_markers_kdl_goTypes = []interface{}{
+	(*StorageVersion)(nil),    // 0: kb.ir.backends.crd.StorageVersion
+	(*UnservedVersion)(nil),   // 1: kb.ir.backends.crd.UnservedVersion
+	(*DeprecatedVersion)(nil), // 2: kb.ir.backends.crd.DeprecatedVersion
+	(*VersionOrder)(nil),      // 3: kb.ir.backends.crd.VersionOrder
+	(*SubresourceStatus)(nil), // 4: kb.ir.backends.crd.SubresourceStatus
+	(*SubresourceScale)(nil),  // 5: kb.ir.backends.crd.SubresourceScale
+	(*PrintColumn)(nil),       // 6: kb.ir.backends.crd.PrintColumn
+	(*Resource)(nil),          // 7: kb.ir.backends.crd.Resource
+}
+var file_tocrd_markers_kdl_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_tocrd_markers_kdl_init() }
+func file_tocrd_markers_kdl_init() {
+	if File_tocrd_markers_kdl != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_tocrd_markers_kdl_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageVersion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tocrd_markers_kdl_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnservedVersion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tocrd_markers_kdl_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeprecatedVersion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tocrd_markers_kdl_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VersionOrder); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tocrd_markers_kdl_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubresourceStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tocrd_markers_kdl_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubresourceScale); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tocrd_markers_kdl_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrintColumn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tocrd_markers_kdl_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Resource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_tocrd_markers_kdl_rawDesc,
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_tocrd_markers_kdl_goTypes,
+		DependencyIndexes: file_tocrd_markers_kdl_depIdxs,
+		MessageInfos:      file_tocrd_markers_kdl_msgTypes,
+	}.Build()
+	File_tocrd_markers_kdl = out.File
+	file_tocrd_markers_kdl_rawDesc = nil
+	file_tocrd_markers_kdl_goTypes = nil
+	file_tocrd_markers_kdl_depIdxs = nil
+}
@@ -317,4 +317,28 @@ func (s UnservedVersion) ApplyToCRD(crd *apiext.CustomResourceDefinitionSpec, ve
 	return nil
 }
 
+// DeprecatedVersion marks this version as deprecated.
+//
+// Clients using a deprecated version receive a warning from the API server.
+type DeprecatedVersion struct {
+	// Warning overrides the default warning returned to clients using this version.
+	Warning string `marker:",optional"`
+}
+
+func (s DeprecatedVersion) ApplyToCRD(crd *apiext.CustomResourceDefinitionSpec, version string) error {
+	for i := range crd.Versions {
+		ver := &crd.Versions[i]
+		if ver.Name != version {
+			continue
+		}
+		ver.Deprecated = true
+		if s.Warning != "" {
+			warning := s.Warning
+			ver.DeprecationWarning = &warning
+		}
+		break
+	}
+	return nil
+}
+
 // NB(directxman12): singular was historically distinct, so we keep it here for backwards compat
@@ -26,7 +26,6 @@ import (
 	"k8s.io/idl/backends/common/request"
 	irt "k8s.io/idl/ckdl-ir/goir/types"
 	irgv "k8s.io/idl/ckdl-ir/goir/groupver"
-	ir "k8s.io/idl/ckdl-ir/goir"
 )
 
 type TypeIdent struct {
@@ -44,7 +43,7 @@ type GroupVersion struct {
 // Most methods on Parser cache their results automatically,
 // and thus may be called any number of times.
 type Parser struct {
-	Loader *request.BackendLoader
+	Loader *request.Loader
 
 	// Types contains the known non-Kind types for this parser.
 	Types map[TypeIdent]*irt.Subtype
@@ -61,6 +60,10 @@ type Parser struct {
 	// GroupVersions keeps track of loaded group-versions
 	GroupVersions map[GroupVersion]*irgv.GroupVersion
 
+	// Errors contains errors encountered while generating
+	// schemata and CRDs.
+	Errors []error
+
 	flattener *Flattener
 }
 
@@ -90,9 +93,37 @@ func (p *Parser) init() {
 	}
 }
 
+// AddError records that an error occurred while generating something.
+// It makes Parser usable as an ErrorRecorder.
+func (p *Parser) AddError(err error) {
+	p.Errors = append(p.Errors, err)
+}
+
+// NodeError is an error about a particular IR node (like a kind) that
+// didn't come from generating a schema (see SchemaError for those).
+type NodeError struct {
+	Node interface{}
+	Err error
+}
+
+// Provenance returns the node the error is about, matching
+// SchemaError.Provenance.
+func (e NodeError) Provenance() (interface{}, []interface{}) {
+	return e.Node, nil
+}
+
+func (e NodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e NodeError) Unwrap() error {
+	return e.Err
+}
+
 // indexTypes loads all types in the package into Types.
-func (p *Parser) indexTypes(set *ir.GroupVersionSet) {
-	for _, gv := range set.GroupVersions {
+func (p *Parser) indexTypes(infos []request.GroupVersionInfo) {
+	for _, info := range infos {
+		gv := info.GroupVersion
 		gvIdent := GroupVersion{Group: gv.Description.Group, Version: gv.Description.Version}
 		for _, kind := range gv.Kinds {
 			p.Kinds[TypeIdent{GroupVersion: gvIdent, Name: kind.Name}] = kind
@@ -116,7 +147,7 @@ func (p *Parser) NeedSchemaFor(typ TypeIdent) {
 	typeInfo, isType := p.Types[typ]
 	kindInfo, isKind := p.Kinds[typ]
 	if !isType && !isKind {
-		panic(fmt.Errorf("unknown type %s", typ))
+		p.AddError(fmt.Errorf("unknown type %s/%s::%s", typ.Group, typ.Version, typ.Name))
 		return
 	}
 	if isType && isKind {
@@ -135,8 +166,8 @@ func (p *Parser) NeedSchemaFor(typ TypeIdent) {
 		p.Schemata[typ] = *schema
 	}
 
-	if errs := schemaCtx.AllErrors(); len(errs) > 0 {
-		panic(fmt.Sprintf("errors: %v", errs))
+	for _, err := range schemaCtx.AllErrors() {
+		p.AddError(err)
 	}
 }
 
@@ -149,7 +180,11 @@ func (p *Parser) NeedFlattenedSchemaFor(typ TypeIdent) {
 
 	p.NeedSchemaFor(typ)
 	partialFlattened := p.flattener.FlattenType(typ)
-	fullyFlattened := FlattenEmbedded(partialFlattened, nil /* todo: package as error reporter */)
+	if partialFlattened == nil {
+		// already recorded an error
+		return
+	}
+	fullyFlattened := FlattenEmbedded(partialFlattened, p)
 
 	p.FlattenedSchemata[typ] = *fullyFlattened
 }
@@ -162,9 +197,11 @@ func (p *Parser) NeedGroupVersion(gv GroupVersion) {
 	if _, present := p.GroupVersions[gv]; present {
 		return
 	}
-	set, err := p.Loader.LoadGroupVersion(request.Hint{Group: gv.Group, Version: gv.Version})
+	infos, err := p.Loader.LoadGroupVersion(request.GroupVersion{Group: gv.Group, Version: gv.Version})
 	if err != nil {
-		panic(err)
+		p.AddError(err)
+		p.GroupVersions[gv] = nil // don't keep retrying
+		return
 	}
-	p.indexTypes(set)
+	p.indexTypes(infos)
 }
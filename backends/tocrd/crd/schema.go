@@ -21,10 +21,15 @@ package crd
 import (
 	"fmt"
 	"encoding/json"
+	"math"
 
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
 	irt "k8s.io/idl/ckdl-ir/goir/types"
+	irc "k8s.io/idl/ckdl-ir/goir/constraints"
+	"k8s.io/idl/backends/common/celname"
 )
 
 type SchemaError struct {
@@ -128,6 +133,7 @@ func KindToSchema(ctx *schemaContext, kind *irt.Kind) *apiext.JSONSchemaProps {
 	schema.Properties["apiVersion"] = apiext.JSONSchemaProps{Type: "string"}
 	schema.Properties["kind"] = apiext.JSONSchemaProps{Type: "string"}
 	schema.Properties["metadata"] = apiext.JSONSchemaProps{Type: "object"}
+	rulesToSchema(ctx, kind.Rules, schema)
 
 	// add in docs
 	docsToSchemaDocs(ctx, kind.Docs, schema)
@@ -194,13 +200,18 @@ func fieldsToSchema(ctx *schemaContext, fields []*irt.Field) *apiext.JSONSchemaP
 		if !field.Optional {
 			props.Required = append(props.Required, propName)
 		}
-
-		/*
-		// TODO: nullable
-		if field.Nullable {
-			propSchema.Nullable = true
+		if field.Default != nil {
+			defaultToSchema(fieldCtx, field, propSchema)
+		}
+		if field.CreateOnly {
+			createOnlyToSchema(fieldCtx, field, props, propSchema)
 		}
-		*/
+		rulesToSchema(fieldCtx, field.Rules, propSchema)
+
+		// NB(directxman12): we never set nullable -- optional fields are
+		// omitted instead of being set to null, and the API server prunes
+		// explicit nulls from non-nullable fields before defaulting, so
+		// a client sending null for a defaulted field gets the default.
 
 		if field.Embedded {
 			props.AllOf = append(props.AllOf, *propSchema)
@@ -211,6 +222,89 @@ func fieldsToSchema(ctx *schemaContext, fields []*irt.Field) *apiext.JSONSchemaP
 	return props
 }
 
+// defaultToSchema sets the default for a field.  Structural schemata require
+// that defaults validate against the field's schema, so we check what we can
+// here (we don't know what references point to till flattening, so we
+// leave checking those to the API server).
+//
+// Note that defaults for fields in nested objects only get applied when the
+// parent object is present -- the API server won't create the parent.
+func defaultToSchema(ctx *schemaContext, field *irt.Field, schema *apiext.JSONSchemaProps) {
+	ctx = ctx.From(field.Default)
+	if field.Embedded {
+		ctx.AddError(field, fmt.Errorf("inline fields may not have defaults"))
+		return
+	}
+
+	var kindOK bool
+	switch val := field.Default.Kind.(type) {
+	case *structpb.Value_StringValue:
+		kindOK = schema.Type == "string" || schema.XIntOrString
+	case *structpb.Value_NumberValue:
+		isInt := val.NumberValue == math.Trunc(val.NumberValue)
+		kindOK = schema.Type == "number" || ((schema.Type == "integer" || schema.XIntOrString) && isInt)
+	case *structpb.Value_BoolValue:
+		kindOK = schema.Type == "boolean"
+	case *structpb.Value_ListValue:
+		kindOK = schema.Type == "array"
+	case *structpb.Value_StructValue:
+		kindOK = schema.Type == "object"
+	default:
+		ctx.AddError(field.Default, fmt.Errorf("unsupported default value"))
+		return
+	}
+	if schema.Ref == nil && !kindOK {
+		ctx.AddError(field.Default, fmt.Errorf("default value %v does not match the type of field %s", field.Default.AsInterface(), field.Name))
+		return
+	}
+
+	raw, err := protojson.Marshal(field.Default)
+	if err != nil {
+		ctx.AddError(field.Default, fmt.Errorf("unable to serialize default value: %w", err))
+		return
+	}
+	schema.Default = &apiext.JSON{Raw: raw}
+}
+
+// createOnlyToSchema makes a field immutable after creation using
+// a transition rule.  Transition rules only run when the field is present
+// in both the old and new objects, so optional fields also get a rule on
+// their parent that keeps them from being added or removed on update.
+func createOnlyToSchema(ctx *schemaContext, field *irt.Field, parent, schema *apiext.JSONSchemaProps) {
+	if field.Embedded {
+		ctx.AddError(field, fmt.Errorf("inline fields may not be create-only"))
+		return
+	}
+
+	schema.XValidations = append(schema.XValidations, apiext.ValidationRule{
+		Rule:    "self == oldSelf",
+		Message: fmt.Sprintf("%s is immutable", field.Name),
+	})
+	if field.Optional {
+		name := celname.Escape(field.Name)
+		parent.XValidations = append(parent.XValidations, apiext.ValidationRule{
+			Rule:    fmt.Sprintf("has(self.%[1]s) == has(oldSelf.%[1]s)", name),
+			Message: fmt.Sprintf("%s may only be set on creation", field.Name),
+		})
+	}
+}
+
+// rulesToSchema adds CEL validation rules to a schema.  The rules were
+// already compiled against the IR types by the compiler, but the API
+// server will check them again (including cost estimates) on its own.
+func rulesToSchema(ctx *schemaContext, rules []*irc.Rule, schema *apiext.JSONSchemaProps) {
+	for _, rule := range rules {
+		res := apiext.ValidationRule{
+			Rule:    rule.Rule,
+			Message: rule.Message,
+		}
+		if rule.FieldPath != "" {
+			res.FieldPath = "." + rule.FieldPath
+		}
+		schema.XValidations = append(schema.XValidations, res)
+	}
+}
+
 func refToSchema(ctx *schemaContext, ref *irt.Reference) *apiext.JSONSchemaProps {
 	ctx = ctx.From(ref)
 	link := TypeRefLink(ref)
@@ -223,14 +317,11 @@ func refToSchema(ctx *schemaContext, ref *irt.Reference) *apiext.JSONSchemaProps
 func structToSchema(ctx *schemaContext, st *irt.Struct) *apiext.JSONSchemaProps {
 	ctx = ctx.From(st)
 	props := fieldsToSchema(ctx, st.Fields)
-	// TODO: atomic
-	/*
 	if st.Atomic {
 		defAtomic := "atomic"
 		props.XMapType = &defAtomic
-		// TODO(directxman12): ask about what's up with this
-	}
-	*/
+	} // default is granular, no need to set explicitly
+	rulesToSchema(ctx, st.Rules, props)
 
 	return props
 }
@@ -239,6 +330,7 @@ func unionToSchema(ctx *schemaContext, st *irt.Union) *apiext.JSONSchemaProps {
 	ctx = ctx.From(st)
 	props := fieldsToSchema(ctx, st.Variants)
 	props.Required = nil // specific requirements done in oneof
+	rulesToSchema(ctx, st.Rules, props)
 
 	if st.Untagged {
 		defOne := int64(1)
@@ -318,12 +410,16 @@ func listToSchema(ctx *schemaContext, list *irt.List) *apiext.JSONSchemaProps {
 	case *irt.List_Reference:
 		valSchema = refToSchema(valueCtx, valType.Reference)
 	default:
-		valueCtx.AddError(valType, fmt.Errorf("invalid set value type"))
+		valueCtx.AddError(valType, fmt.Errorf("invalid list value type"))
 	}
 	valueCtx.Makes(valSchema)
+	// plain lists are always atomic -- granular merging is what
+	// sets & list-maps are for
+	defAtomic := "atomic"
 	schema := &apiext.JSONSchemaProps{
 		Type: "array",
 		Items: &apiext.JSONSchemaPropsOrArray{Schema: valSchema},
+		XListType: &defAtomic,
 	}
 	return schema
 }
@@ -354,13 +450,10 @@ func primitiveMapToSchema(ctx *schemaContext, primMap *irt.PrimitiveMap) *apiext
 			Allows: true, /* set automatically by serialization, but useful for testing */
 		},
 	}
-	// TODO: atomic
-	/*
 	if primMap.Atomic {
 		defAtomic := "atomic"
 		schema.XMapType = &defAtomic
 	} // default is granular, no need to set explicitly
-	*/
 
 	return schema
 }
@@ -435,7 +528,7 @@ func primitiveToSchema(ctx *schemaContext, primitive *irt.Primitive) *apiext.JSO
 		typ, format = "string", "byte"
 	case irt.Primitive_LEGACYFLOAT64:
 		typ = "number"
-	case 100: // TODO: intorstring
+	case irt.Primitive_INTORSTRING:
 		schema = &apiext.JSONSchemaProps{
 			XIntOrString: true,
 			AnyOf: []apiext.JSONSchemaProps{
@@ -26,6 +26,8 @@ import (
 
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	irt "k8s.io/idl/ckdl-ir/goir/types"
 )
 
 // SpecMarker is a marker that knows how to apply itself to a particular
@@ -41,9 +43,9 @@ type GroupKind struct {
 	Kind string
 }
 
-// NeedCRDFor requests the full CRD for the given group-kind.  It requires
-// that the packages containing the Go structs for that CRD have already
-// been loaded with NeedPackage.
+// NeedCRDFor requests the full CRD for the given group-kind.  Every
+// version of the group known to the loader is considered, and the
+// CRD-level markers on each version of the kind are applied.
 func (p *Parser) NeedCRDFor(groupKind GroupKind, maxDescLen *int) {
 	p.init()
 
@@ -51,6 +53,17 @@ func (p *Parser) NeedCRDFor(groupKind GroupKind, maxDescLen *int) {
 		return
 	}
 
+	// make sure we've got every version of the group, not just the
+	// ones we happened to load types from
+	if p.Loader != nil {
+		for gv := range p.Loader.GroupVersions() {
+			if gv.Group != groupKind.Group {
+				continue
+			}
+			p.NeedGroupVersion(GroupVersion{Group: gv.Group, Version: gv.Version})
+		}
+	}
+
 	var gvs []GroupVersion
 	for gv := range p.GroupVersions {
 		if gv.Group != groupKind.Group {
@@ -91,6 +104,9 @@ func (p *Parser) NeedCRDFor(groupKind GroupKind, maxDescLen *int) {
 		if maxDescLen != nil {
 			TruncateDescription(&fullSchema, *maxDescLen)
 		}
+		for _, err := range checkTransitionRules(&fullSchema, "", true) {
+			p.AddError(NodeError{Node: p.Kinds[typeIdent], Err: fmt.Errorf("%s/%s::%s: %w", gv.Group, gv.Version, groupKind.Kind, err)})
+		}
 		ver := apiext.CustomResourceDefinitionVersion{
 			Name:   gv.Version,
 			Served: true,
@@ -102,27 +118,37 @@ func (p *Parser) NeedCRDFor(groupKind GroupKind, maxDescLen *int) {
 	}
 
 	// markers are applied *after* initial generation of objects
-	// TODO: attributes
-	// for _, pkg := range packages {
-	// 	typeIdent := TypeIdent{Package: pkg, Name: groupKind.Kind}
-	// 	typeInfo := p.Types[typeIdent]
-	// 	if typeInfo == nil {
-	// 		continue
-	// 	}
-	// 	ver := p.GroupVersions[pkg].Version
-
-	// 	for _, markerVals := range typeInfo.Markers {
-	// 		for _, val := range markerVals {
-	// 			crdMarker, isCrdMarker := val.(SpecMarker)
-	// 			if !isCrdMarker {
-	// 				continue
-	// 			}
-	// 			if err := crdMarker.ApplyToCRD(&crd.Spec, ver); err != nil {
-	// 				pkg.AddError(loader.ErrFromNode(err /* an okay guess */, typeInfo.RawSpec))
-	// 			}
-	// 		}
-	// 	}
-	// }
+	versionOrder := make(map[string]int32)
+	// kindsByVersion lets CRD-wide errors point at a version of the kind
+	kindsByVersion := make(map[string]*irt.Kind)
+	for _, gv := range gvs {
+		typeIdent := TypeIdent{GroupVersion: gv, Name: groupKind.Kind}
+		kindInfo := p.Kinds[typeIdent]
+		if kindInfo == nil {
+			continue
+		}
+		kindsByVersion[gv.Version] = kindInfo
+
+		specMarkers, err := specMarkersFor(kindInfo.Attributes)
+		if err != nil {
+			p.AddError(NodeError{Node: kindInfo, Err: fmt.Errorf("%s/%s::%s: %w", gv.Group, gv.Version, groupKind.Kind, err)})
+			continue
+		}
+		for _, specMarker := range specMarkers {
+			if err := specMarker.ApplyToCRD(&crd.Spec, gv.Version); err != nil {
+				p.AddError(NodeError{Node: kindInfo, Err: fmt.Errorf("%s/%s::%s: %w", gv.Group, gv.Version, groupKind.Kind, err)})
+			}
+		}
+
+		order, hasOrder, err := versionOrderFor(kindInfo.Attributes)
+		if err != nil {
+			p.AddError(NodeError{Node: kindInfo, Err: fmt.Errorf("%s/%s::%s: %w", gv.Group, gv.Version, groupKind.Kind, err)})
+			continue
+		}
+		if hasOrder {
+			versionOrder[gv.Version] = order
+		}
+	}
 
 	// fix the name if the plural was changed (this is the form the name *has* to take, so no harm in changing it).
 	crd.Name = crd.Spec.Names.Plural + "." + groupKind.Group
@@ -134,25 +160,48 @@ func (p *Parser) NeedCRDFor(groupKind GroupKind, maxDescLen *int) {
 
 	// it is necessary to make sure the order of CRD versions in crd.Spec.Versions is stable and explicitly set crd.Spec.Version.
 	// Otherwise, crd.Spec.Version may point to different CRD versions across different runs.
-	sort.Slice(crd.Spec.Versions, func(i, j int) bool { return crd.Spec.Versions[i].Name < crd.Spec.Versions[j].Name })
+	// Versions with an explicit order come first, then the rest by name.
+	sort.Slice(crd.Spec.Versions, func(i, j int) bool {
+		iName, jName := crd.Spec.Versions[i].Name, crd.Spec.Versions[j].Name
+		iOrder, iHasOrder := versionOrder[iName]
+		jOrder, jHasOrder := versionOrder[jName]
+		switch {
+		case iHasOrder && jHasOrder && iOrder != jOrder:
+			return iOrder < jOrder
+		case iHasOrder != jHasOrder:
+			return iHasOrder
+		default:
+			return iName < jName
+		}
+	})
 
-	// make sure we have *a* storage version
+	// make sure we have exactly one storage version
 	// (default it if we only have one, otherwise, bail)
 	if len(crd.Spec.Versions) == 1 {
 		crd.Spec.Versions[0].Storage = true
 	}
-
-	hasStorage := false
+	var storageVersions []string
 	for _, ver := range crd.Spec.Versions {
 		if ver.Storage {
-			hasStorage = true
-			break
+			storageVersions = append(storageVersions, ver.Name)
 		}
 	}
-	if !hasStorage {
-		// just add the error to the first relevant package for this CRD,
-		// since there's no specific error location
-		panic(fmt.Errorf("CRD for %s has no storage version", groupKind))
+	switch len(storageVersions) {
+	case 1:
+		// all good
+	case 0:
+		p.AddError(NodeError{
+			Node: kindsByVersion[crd.Spec.Versions[0].Name],
+			Err: fmt.Errorf("CRD for %s/%s has no storage version (mark exactly one version with storage-version)", groupKind.Group, groupKind.Kind),
+		})
+		return
+	default:
+		// point at the first version that conflicts with the others
+		p.AddError(NodeError{
+			Node: kindsByVersion[storageVersions[1]],
+			Err: fmt.Errorf("CRD for %s/%s has multiple storage versions %v (mark exactly one version with storage-version)", groupKind.Group, groupKind.Kind, storageVersions),
+		})
+		return
 	}
 
 	served := false
@@ -163,9 +212,11 @@ func (p *Parser) NeedCRDFor(groupKind GroupKind, maxDescLen *int) {
 		}
 	}
 	if !served {
-		// just add the error to the first relevant package for this CRD,
-		// since there's no specific error location
-		panic(fmt.Errorf("CRD for %s with version(s) %v does not serve any version", groupKind, crd.Spec.Versions))
+		p.AddError(NodeError{
+			Node: kindsByVersion[crd.Spec.Versions[0].Name],
+			Err: fmt.Errorf("CRD for %s/%s does not serve any version", groupKind.Group, groupKind.Kind),
+		})
+		return
 	}
 
 	// NB(directxman12): CRD's status doesn't have omitempty markers, which means things
@@ -176,3 +227,32 @@ func (p *Parser) NeedCRDFor(groupKind GroupKind, maxDescLen *int) {
 
 	p.CustomResourceDefinitions[groupKind] = crd
 }
+
+// checkTransitionRules checks that validation rules that refer to oldSelf
+// (e.g. from create-only) only appear where the API server can correlate
+// the old and new values of a field.  Anything under a list that isn't
+// a list-map can't be correlated.
+func checkTransitionRules(schema *apiext.JSONSchemaProps, path string, correlatable bool) []error {
+	var errs []error
+	if !correlatable {
+		for _, rule := range schema.XValidations {
+			if strings.Contains(rule.Rule, "oldSelf") {
+				errs = append(errs, fmt.Errorf("field %s is create-only or has a transition rule, but is inside a list that isn't a list-map, so old and new values can't be compared", path))
+				break
+			}
+		}
+	}
+
+	for name, prop := range schema.Properties {
+		prop := prop
+		errs = append(errs, checkTransitionRules(&prop, path+"."+name, correlatable)...)
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		errs = append(errs, checkTransitionRules(schema.AdditionalProperties.Schema, path+"[*]", correlatable)...)
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		isListMap := schema.XListType != nil && *schema.XListType == "map"
+		errs = append(errs, checkTransitionRules(schema.Items.Schema, path+"[*]", correlatable && isListMap)...)
+	}
+	return errs
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+
+// Package tocrd is a backend that generates CustomResourceDefinitions for
+// the requested kinds.
+package tocrd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"k8s.io/idl/backends/common/backend"
+	"k8s.io/idl/backends/common/request"
+	"k8s.io/idl/backends/common/respond"
+
+	"k8s.io/idl/backends/tocrd/crd"
+)
+
+// Backend is the CRD backend.
+var Backend backend.Backend = backend.Func(generate)
+
+func generate(_ context.Context, req *request.Request, resp *respond.Responder) error {
+	if err := req.Options.CheckKnown(); err != nil {
+		return err
+	}
+
+	parser := &crd.Parser{Loader: req.Loader}
+
+	hadErr := false
+	failed := make(map[crd.GroupKind]bool)
+	for _, typ := range req.Types {
+		ident := crd.TypeIdent{
+			GroupVersion: crd.GroupVersion{Group: typ.Group, Version: typ.Version},
+			Name: typ.Type,
+		}
+		groupKind := crd.GroupKind{Group: ident.Group, Kind: ident.Name}
+		if failed[groupKind] {
+			// already reported for another version of the kind
+			continue
+		}
+		errsBefore := len(parser.Errors)
+		parser.NeedGroupVersion(ident.GroupVersion)
+		parser.NeedSchemaFor(ident)
+		parser.NeedCRDFor(groupKind, nil)
+
+		outCRD, present := parser.CustomResourceDefinitions[groupKind]
+		if !present {
+			// if the parser said why, that's reported below
+			if len(parser.Errors) == errsBefore {
+				resp.GeneralError(nil, "no CRD found", "group", ident.Group, "version", ident.Version, "kind", ident.Name)
+			}
+			failed[groupKind] = true
+			hadErr = true
+			continue
+		}
+
+		asJSON, err := json.Marshal(outCRD)
+		if err != nil {
+			return fmt.Errorf("unable to serialize CRD for %s: %w", typ, err)
+		}
+		var out bytes.Buffer
+		json.Indent(&out, asJSON, "", "  ")
+		out.WriteString("\n")
+		resp.File(fmt.Sprintf("%s_%s.json", outCRD.Spec.Group, outCRD.Spec.Names.Plural), out.Bytes())
+	}
+
+	for _, err := range parser.Errors {
+		resp.ErrorAt(errorNode(resp, err), err, "unable to generate CRD")
+		hadErr = true
+	}
+
+	if hadErr {
+		return backend.ErrReported
+	}
+	return nil
+}
+
+// errorNode finds the innermost IR node that a parser error came from,
+// so that the error can point at the KDL responsible.
+func errorNode(resp *respond.Responder, err error) *respond.Tracker {
+	// both crd.SchemaError & crd.NodeError know where they came from
+	var withProvenance interface {
+		Provenance() (interface{}, []interface{})
+	}
+	if !errors.As(err, &withProvenance) {
+		return nil
+	}
+	hint, provenance := withProvenance.Provenance()
+	for _, item := range append([]interface{}{hint}, provenance...) {
+		// some items are oneof wrappers & the like, which aren't nodes
+		node, isNode := item.(proto.Message)
+		if !isNode {
+			continue
+		}
+		if tracker := resp.Node(node); tracker != nil {
+			return tracker
+		}
+	}
+	return nil
+}
@@ -0,0 +1,517 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package tokgo
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+
+	"k8s.io/idl/backends/common/smd"
+	"k8s.io/idl/backends/common/typegraph"
+	ir "k8s.io/idl/ckdl-ir/goir"
+	irt "k8s.io/idl/ckdl-ir/goir/types"
+)
+
+// NB(directxman12): applyconfiguration-gen has to reverse-engineer the
+// list & map topology from Go types & markers, and then needs a separate
+// OpenAPI schema to build the structured-merge-diff schema from.  We've got
+// all that in the IR already, so we produce both straight from there.
+
+const applyMetaPath = "k8s.io/client-go/applyconfigurations/meta/v1"
+
+// applyPackage returns the import path of the apply configurations for the
+// types in the given package.
+func applyPackage(pkg goPackage) string {
+	return pkg.ImportPath + "/applyconfiguration"
+}
+
+// schemaNamer names types in the embedded schema after their Go package,
+// like OpenAPI definition names (e.g. example.com/apis/v1.Widget becomes
+// com.example.apis.v1.Widget).  This happens to match apimachinery's own
+// names for its meta types.
+func schemaNamer(packages goPackages, types *typegraph.Graph) smd.Namer {
+	return func(name typegraph.Name) string {
+		parts := strings.Split(packages.For(name.GroupVersion).ImportPath, "/")
+		domain := strings.Split(parts[0], ".")
+		for i, j := 0, len(domain)-1; i < j; i, j = i+1, j-1 {
+			domain[i], domain[j] = domain[j], domain[i]
+		}
+		parts[0] = strings.Join(domain, ".")
+		return strings.Join(parts, ".") + "." + nameType(name.FullName, types.Attributes(name.Ref()))
+	}
+}
+
+// buildApplySchema collects the structured-merge-diff schema for every type
+// in the given group-version (and everything they reference), returning the
+// serialized schema and the names of the kinds within it.
+func buildApplySchema(inputs []*ir.GroupVersion, gv groupVersion, packages goPackages, index *typeIndex) ([]byte, map[typeKey]string, error) {
+	builder := smd.NewBuilder(index.Graph, schemaNamer(packages, index.Graph))
+	kindNames := make(map[typeKey]string)
+	for _, gvIR := range inputs {
+		for _, kind := range gvIR.Kinds {
+			key := typeKey{GroupVersion: gv, FullName: kind.Name}
+			schemaName, err := builder.Add(key)
+			if err != nil {
+				return nil, nil, fmt.Errorf("kind %s: %w", kind.Name, err)
+			}
+			kindNames[key] = schemaName
+		}
+		for _, subtype := range gvIR.Types {
+			if _, err := builder.Add(typeKey{GroupVersion: gv, FullName: subtype.Name}); err != nil {
+				return nil, nil, fmt.Errorf("type %s: %w", subtype.Name, err)
+			}
+		}
+	}
+	schemaYAML, err := builder.YAML()
+	if err != nil {
+		return nil, nil, err
+	}
+	return schemaYAML, kindNames, nil
+}
+
+// writeApplyInternal writes the internal package that holds the embedded
+// schema, like the one applyconfiguration-gen produces.
+func writeApplyInternal(schemaYAML []byte, out *pkgWriter) {
+	fmt.Fprint(out.header, "// Code generated by ckdl-to-tokgo. DO NOT EDIT.\n\n")
+	fmt.Fprint(out.header, "package internal\n\n")
+	fmtPkg := out.Import("fmt", "fmt")
+	syncPkg := out.Import("sync", "sync")
+	typed := out.Import("sigs.k8s.io/structured-merge-diff/v4/typed", "typed")
+
+	code := new(bytes.Buffer)
+	fmt.Fprintf(code, "func Parser() *%s.Parser {\n", typed)
+	fmt.Fprintln(code, "parserOnce.Do(func() {")
+	fmt.Fprintln(code, "var err error")
+	fmt.Fprintf(code, "parser, err = %s.NewParser(schemaYAML)\n", typed)
+	fmt.Fprintf(code, "if err != nil {\npanic(%s.Sprintf(\"Failed to parse schema: %%v\", err))\n}\n", fmtPkg)
+	fmt.Fprintln(code, "})")
+	fmt.Fprintln(code, "return parser\n}")
+	fmt.Fprintln(code, "")
+	fmt.Fprintf(code, "var parserOnce %s.Once\n", syncPkg)
+	fmt.Fprintf(code, "var parser *%s.Parser\n", typed)
+	if bytes.ContainsRune(schemaYAML, '`') {
+		fmt.Fprintf(code, "var schemaYAML = %s.YAMLObject(%q)\n", typed, schemaYAML)
+	} else {
+		fmt.Fprintf(code, "var schemaYAML = %s.YAMLObject(`%s`)\n", typed, schemaYAML)
+	}
+	out.Code("Parser", code)
+}
+
+// applyWriter writes apply configurations for a group-version, which live
+// in their own package next to the types.
+type applyWriter struct {
+	*pkgWriter
+	index *typeIndex
+	// schemaNames holds the names of the kinds in the embedded schema (see
+	// buildApplySchema)
+	schemaNames map[typeKey]string
+}
+
+// applyRef returns the apply configuration type for the given struct,
+// union, or kind, which might live in another group-version's package.
+func (w *applyWriter) applyRef(name typeKey) string {
+	typeName := nameType(name.FullName, w.Types.Attributes(name.Ref())) + "ApplyConfiguration"
+	if name.GroupVersion == w.CurrentGV {
+		return typeName
+	}
+	apiRef := w.MakeGVRef(name.Ref())
+	alias := apiRef[:strings.LastIndex(apiRef, ".")] + "apply"
+	return w.Import(applyPackage(w.Packages.For(name.GroupVersion)), alias) + "." + typeName
+}
+
+// configRef returns the apply configuration for the type the given
+// reference eventually points to, if it's got one.  Everything else is set
+// using its API type.
+func (w *applyWriter) configRef(ref *irt.Reference) (string, bool) {
+	name, term, known := w.Types.Follow(ref)
+	if !known {
+		return "", false
+	}
+	switch term.(type) {
+	case typegraph.TerminalStruct, typegraph.TerminalUnion, typegraph.TerminalKind:
+		return w.applyRef(name), true
+	default:
+		return "", false
+	}
+}
+
+// applyFieldKind describes how the With function for a field sets it.
+type applyFieldKind int
+
+const (
+	// applyScalar fields are pointers to their value
+	applyScalar applyFieldKind = iota
+	// applyConfig fields are pointers to an apply configuration
+	applyConfig
+	// applyValue fields are named slices & maps, which are set as a whole
+	applyValue
+	// applySlice fields are appended to
+	applySlice
+	// applyMap fields have entries put into them
+	applyMap
+)
+
+// applyField is a field of an apply configuration.
+type applyField struct {
+	name     string
+	jsonName string
+	kind     applyFieldKind
+	// typeStr is the type of the field itself
+	typeStr string
+	// elem is the parameter type of the With function (for slices, the
+	// type of each item)
+	elem string
+	// elemIsConfig marks slices of apply configurations, which are passed
+	// in as pointers
+	elemIsConfig bool
+	// ensure is called before setting the field, for fields that live in
+	// an embedded pointer
+	ensure string
+}
+
+func (w *applyWriter) fieldFor(field *irt.Field) applyField {
+	res := applyField{name: nameField(field.Name, field.Attributes), jsonName: field.Name}
+	switch typ := field.Type.(type) {
+	case *irt.Field_Primitive:
+		if typ.Primitive.Type == irt.Primitive_BYTES {
+			res.kind, res.typeStr, res.elem = applySlice, "[]byte", "byte"
+			break
+		}
+		res.elem = primType(typ.Primitive, false, w)
+		res.kind, res.typeStr = applyScalar, "*"+res.elem
+	case *irt.Field_NamedType:
+		if config, isConfig := w.configRef(typ.NamedType); isConfig {
+			res.kind, res.typeStr, res.elem = applyConfig, "*"+config, config
+			break
+		}
+		res.elem = w.MakeGVRef(typ.NamedType)
+		if isPointerField(w.Types, &irt.Field{Type: typ, Optional: true}) {
+			res.kind, res.typeStr = applyScalar, "*"+res.elem
+		} else {
+			res.kind, res.typeStr = applyValue, res.elem
+		}
+	case *irt.Field_Set:
+		switch items := typ.Set.Items.(type) {
+		case *irt.Set_Primitive:
+			w.sliceOfPrim(&res, items.Primitive)
+		case *irt.Set_Reference:
+			w.sliceOfRef(&res, items.Reference)
+		}
+	case *irt.Field_List:
+		switch items := typ.List.Items.(type) {
+		case *irt.List_Primitive:
+			w.sliceOfPrim(&res, items.Primitive)
+		case *irt.List_Reference:
+			w.sliceOfRef(&res, items.Reference)
+		}
+	case *irt.Field_ListMap:
+		w.sliceOfRef(&res, typ.ListMap.Items)
+	case *irt.Field_PrimitiveMap:
+		// map values are rarely more than a string, so (like
+		// applyconfiguration-gen) we just use the API types
+		mapType := primMapType(typ.PrimitiveMap, &tags{}, &comment{}, w)
+		res.kind, res.typeStr = applyMap, mapType
+	default:
+		panic(fmt.Sprintf("unreachable: unknown field type %T", typ))
+	}
+	return res
+}
+
+func (w *applyWriter) sliceOfPrim(res *applyField, prim *irt.Primitive) {
+	res.kind, res.elem = applySlice, primType(prim, false, w)
+	res.typeStr = "[]" + res.elem
+}
+
+func (w *applyWriter) sliceOfRef(res *applyField, ref *irt.Reference) {
+	res.kind = applySlice
+	if config, isConfig := w.configRef(ref); isConfig {
+		res.elem, res.elemIsConfig = config, true
+	} else {
+		res.elem = w.MakeGVRef(ref)
+	}
+	res.typeStr = "[]" + res.elem
+}
+
+// embeddedFields returns the fields of the struct that an embedded field
+// refers to, which get promoted into the parent.
+func (w *applyWriter) embeddedFields(field *irt.Field) (typeKey, []*irt.Field) {
+	named, isNamed := field.Type.(*irt.Field_NamedType)
+	if !isNamed {
+		panic(fmt.Sprintf("unreachable: embedded field %q isn't a reference", field.Name))
+	}
+	name, term, _ := w.Types.Follow(named.NamedType)
+	switch term := term.(type) {
+	case typegraph.TerminalStruct:
+		return name, term.Struct.Fields
+	case typegraph.TerminalKind:
+		return name, term.Kind.Fields
+	default:
+		panic(fmt.Sprintf("unreachable: embedded field %q doesn't refer to a struct", field.Name))
+	}
+}
+
+// setterFields flattens the given fields (including the fields of embedded
+// structs), since each one gets a With function on the parent.
+func (w *applyWriter) setterFields(fields []*irt.Field) []applyField {
+	var res []applyField
+	for _, field := range fields {
+		if field.Embedded {
+			_, embedded := w.embeddedFields(field)
+			res = append(res, w.setterFields(embedded)...)
+			continue
+		}
+		res = append(res, w.fieldFor(field))
+	}
+	return res
+}
+
+func writeWith(out *bytes.Buffer, recv string, field applyField) {
+	ensure := ""
+	if field.ensure != "" {
+		ensure = "b." + field.ensure + "()\n"
+	}
+	switch field.kind {
+	case applySlice:
+		fmt.Fprintf(out, "// With%[1]s adds the given value to the %[1]s field in the declarative configuration\n", field.name)
+		fmt.Fprintln(out, "// and returns the receiver, so that objects can be built by chaining \"With\" function invocations.")
+		fmt.Fprintf(out, "// If called multiple times, values provided by each call will be appended to the %s field.\n", field.name)
+		if field.elemIsConfig {
+			fmt.Fprintf(out, "func (b *%s) With%s(values ...*%s) *%s {\n%s", recv, field.name, field.elem, recv, ensure)
+			fmt.Fprintf(out, "for i := range values {\nif values[i] == nil {\npanic(\"nil value passed to With%s\")\n}\n", field.name)
+			fmt.Fprintf(out, "b.%[1]s = append(b.%[1]s, *values[i])\n}\n", field.name)
+		} else {
+			fmt.Fprintf(out, "func (b *%s) With%s(values ...%s) *%s {\n%s", recv, field.name, field.elem, recv, ensure)
+			fmt.Fprintf(out, "for i := range values {\nb.%[1]s = append(b.%[1]s, values[i])\n}\n", field.name)
+		}
+	case applyMap:
+		fmt.Fprintf(out, "// With%[1]s puts the entries into the %[1]s field in the declarative configuration\n", field.name)
+		fmt.Fprintln(out, "// and returns the receiver, so that objects can be built by chaining \"With\" function invocations.")
+		fmt.Fprintf(out, "// If called multiple times, the entries provided by each call will be put on the %[1]s field,\n// overwriting an existing map entries in %[1]s field with the same key.\n", field.name)
+		fmt.Fprintf(out, "func (b *%s) With%s(entries %s) *%s {\n%s", recv, field.name, field.typeStr, recv, ensure)
+		fmt.Fprintf(out, "if b.%[1]s == nil && len(entries) > 0 {\nb.%[1]s = make(%[2]s, len(entries))\n}\n", field.name, field.typeStr)
+		fmt.Fprintf(out, "for k, v := range entries {\nb.%s[k] = v\n}\n", field.name)
+	default:
+		fmt.Fprintf(out, "// With%[1]s sets the %[1]s field in the declarative configuration to the given value\n", field.name)
+		fmt.Fprintln(out, "// and returns the receiver, so that objects can be built by chaining \"With\" function invocations.")
+		fmt.Fprintf(out, "// If called multiple times, the %s field is set to the value of the last call.\n", field.name)
+		param := field.elem
+		if field.kind == applyConfig {
+			param = "*" + param
+		}
+		fmt.Fprintf(out, "func (b *%s) With%s(value %s) *%s {\n%s", recv, field.name, param, recv, ensure)
+		if field.kind == applyScalar {
+			fmt.Fprintf(out, "b.%s = &value\n", field.name)
+		} else {
+			fmt.Fprintf(out, "b.%s = value\n", field.name)
+		}
+	}
+	fmt.Fprint(out, "return b\n}\n\n")
+}
+
+// writeConfigFields writes the struct fields of an apply configuration.
+// Everything is optional, since appliers only set the fields they care
+// about.
+func (w *applyWriter) writeConfigFields(fields []*irt.Field, out *subWriter) {
+	for _, field := range fields {
+		if field.Embedded {
+			name, _ := w.embeddedFields(field)
+			out.Field("", w.applyRef(name), tags{json: tag{"", "inline"}}, comment{})
+			continue
+		}
+		config := w.fieldFor(field)
+		out.Field(config.name, config.typeStr, tags{json: tag{config.jsonName, "omitempty"}}, comment{})
+	}
+}
+
+func writeApplyConfigurations(inputs []*ir.GroupVersion, out *applyWriter) {
+	fmt.Fprint(out.header, "// Code generated by ckdl-to-tokgo. DO NOT EDIT.\n\n")
+	out.Package(comment{})
+
+	for _, gv := range inputs {
+		for _, kind := range gv.Kinds {
+			out.writeKind(kind)
+		}
+		for _, subtype := range gv.Types {
+			typeName := nameType(subtype.Name, subtype.Attributes)
+			switch body := subtype.Type.(type) {
+			case *irt.Subtype_Struct:
+				out.writeStruct(typeName, body.Struct.Fields, nil)
+			case *irt.Subtype_Union:
+				union := body.Union
+				var tagField *applyField
+				if !union.Untagged {
+					tagType := out.MakeGVRef(typeKey{GroupVersion: out.CurrentGV, FullName: subtype.Name}.Ref()) + "Type"
+					tagField = &applyField{
+						name:     strings.Title(union.Tag),
+						jsonName: union.Tag,
+						kind:     applyScalar,
+						typeStr:  "*" + tagType,
+						elem:     tagType,
+					}
+				}
+				out.writeStruct(typeName, unionVariants(union), tagField)
+			}
+			// everything else is set using the API types directly
+		}
+	}
+}
+
+// writeStruct writes the apply configuration for a struct or union (whose
+// tag is passed separately).
+func (w *applyWriter) writeStruct(typeName string, fields []*irt.Field, tagField *applyField) {
+	configName := typeName + "ApplyConfiguration"
+	w.BlockType(configName, comment{doc: &irt.Documentation{
+		Description: fmt.Sprintf("%s represents a declarative configuration of the %s type for use\nwith apply.", configName, typeName),
+	}}, func(out *subWriter) {
+		if tagField != nil {
+			out.Field(tagField.name, tagField.typeStr, tags{json: tag{tagField.jsonName, "omitempty"}}, comment{})
+		}
+		w.writeConfigFields(fields, out)
+	})
+
+	code := new(bytes.Buffer)
+	fmt.Fprintf(code, "// %s constructs a declarative configuration of the %s type for use with\n// apply.\n", typeName, typeName)
+	fmt.Fprintf(code, "func %s() *%s {\nreturn &%s{}\n}\n\n", typeName, configName, configName)
+	if tagField != nil {
+		writeWith(code, configName, *tagField)
+	}
+	for _, field := range w.setterFields(fields) {
+		writeWith(code, configName, field)
+	}
+	w.Code(configName+"With", code)
+}
+
+func (w *applyWriter) writeKind(kind *irt.Kind) {
+	key := typeKey{GroupVersion: w.CurrentGV, FullName: kind.Name}
+	typeName := nameType(kind.Name, kind.Attributes)
+	configName := typeName + "ApplyConfiguration"
+	applyMeta := w.Import(applyMetaPath, "applymetav1")
+	apiVersion := w.CurrentGV.Version
+	if w.CurrentGV.Group != "" {
+		apiVersion = w.CurrentGV.Group + "/" + w.CurrentGV.Version
+	}
+
+	w.BlockType(configName, comment{doc: &irt.Documentation{
+		Description: fmt.Sprintf("%s represents a declarative configuration of the %s type for use\nwith apply.", configName, typeName),
+	}}, func(out *subWriter) {
+		out.Field("", applyMeta+".TypeMetaApplyConfiguration", tags{json: tag{"", "inline"}}, comment{})
+		if kind.Object {
+			out.Field("", "*"+applyMeta+".ObjectMetaApplyConfiguration", tags{json: tag{"metadata", "omitempty"}}, comment{})
+		}
+		w.writeConfigFields(kind.Fields, out)
+	})
+
+	code := new(bytes.Buffer)
+	fmt.Fprintf(code, "// %s constructs a declarative configuration of the %s type for use with\n// apply.\n", typeName, typeName)
+	if kind.Object {
+		fmt.Fprintf(code, "func %s(name, namespace string) *%s {\n", typeName, configName)
+		fmt.Fprintf(code, "b := &%s{}\nb.WithName(name)\nb.WithNamespace(namespace)\n", configName)
+	} else {
+		fmt.Fprintf(code, "func %s() *%s {\nb := &%s{}\n", typeName, configName, configName)
+	}
+	fmt.Fprintf(code, "b.WithKind(%q)\nb.WithAPIVersion(%q)\nreturn b\n}\n\n", typeName, apiVersion)
+
+	if kind.Object {
+		w.writeExtract(code, key, typeName, apiVersion, kind)
+	}
+
+	setters := []applyField{
+		{name: "Kind", kind: applyScalar, elem: "string"},
+		{name: "APIVersion", kind: applyScalar, elem: "string"},
+	}
+	if kind.Object {
+		setters = append(setters, w.objectMetaFields()...)
+	}
+	for _, field := range append(setters, w.setterFields(kind.Fields)...) {
+		writeWith(code, configName, field)
+	}
+	if kind.Object {
+		fmt.Fprintf(code, "func (b *%s) ensureObjectMetaApplyConfigurationExists() {\n", configName)
+		fmt.Fprintf(code, "if b.ObjectMetaApplyConfiguration == nil {\nb.ObjectMetaApplyConfiguration = &%s.ObjectMetaApplyConfiguration{}\n}\n}\n\n", applyMeta)
+	}
+	w.Code(configName+"With", code)
+}
+
+// writeExtract writes the functions that pull out the fields a given field
+// manager owns from an existing object, using the embedded schema.
+func (w *applyWriter) writeExtract(out *bytes.Buffer, key typeKey, typeName, apiVersion string, kind *irt.Kind) {
+	configName := typeName + "ApplyConfiguration"
+	apiType := w.MakeGVRef(key.Ref())
+	managedFields := w.Import("k8s.io/apimachinery/pkg/util/managedfields", "managedfields")
+	internal := w.Import(applyPackage(w.Packages.For(w.CurrentGV))+"/internal", "internal")
+
+	fmt.Fprintf(out, "// Extract%s extracts the applied configuration owned by fieldManager from\n", typeName)
+	fmt.Fprintf(out, "// obj. If no managedFields are found in obj for fieldManager, a\n")
+	fmt.Fprintf(out, "// %s is returned with only the Name, Namespace (if applicable),\n", configName)
+	fmt.Fprintln(out, "// APIVersion and Kind populated.  That may be because other field managers")
+	fmt.Fprintln(out, "// have taken ownership of all the fields fieldManager used to own, or because")
+	fmt.Fprintln(out, "// fieldManager never owned any fields.")
+	fmt.Fprintln(out, "// Experimental!")
+	fmt.Fprintf(out, "func Extract%s(obj *%s, fieldManager string) (*%s, error) {\n", typeName, apiType, configName)
+	fmt.Fprintf(out, "return extract%s(obj, fieldManager, \"\")\n}\n\n", typeName)
+
+	for _, field := range kind.Fields {
+		if field.Name != "status" {
+			continue
+		}
+		fmt.Fprintf(out, "// Extract%sStatus is the same as Extract%s except\n", typeName, typeName)
+		fmt.Fprintln(out, "// that it extracts the status subresource applied configuration.")
+		fmt.Fprintln(out, "// Experimental!")
+		fmt.Fprintf(out, "func Extract%sStatus(obj *%s, fieldManager string) (*%s, error) {\n", typeName, apiType, configName)
+		fmt.Fprintf(out, "return extract%s(obj, fieldManager, \"status\")\n}\n\n", typeName)
+	}
+
+	fmt.Fprintf(out, "func extract%s(obj *%s, fieldManager string, subresource string) (*%s, error) {\n", typeName, apiType, configName)
+	fmt.Fprintf(out, "b := &%s{}\n", configName)
+	fmt.Fprintf(out, "err := %s.ExtractInto(obj, %s.Parser().Type(%q), fieldManager, b, subresource)\n", managedFields, internal, w.schemaNames[key])
+	fmt.Fprintln(out, "if err != nil {\nreturn nil, err\n}")
+	fmt.Fprintln(out, "b.WithName(obj.Name)\nb.WithNamespace(obj.Namespace)")
+	fmt.Fprintf(out, "b.WithKind(%q)\nb.WithAPIVersion(%q)\nreturn b, nil\n}\n\n", typeName, apiVersion)
+}
+
+// objectMetaFields are the With functions for the fields of ObjectMeta
+// that appliers may set, which we promote like applyconfiguration-gen does.
+func (w *applyWriter) objectMetaFields() []applyField {
+	const ensure = "ensureObjectMetaApplyConfigurationExists"
+	timeType := w.MakeGVRef(timeRef)
+	uidType := w.Import("k8s.io/apimachinery/pkg/types", "types") + ".UID"
+	ownerRef := w.Import(applyMetaPath, "applymetav1") + ".OwnerReferenceApplyConfiguration"
+	return []applyField{
+		{name: "Name", kind: applyScalar, elem: "string", ensure: ensure},
+		{name: "GenerateName", kind: applyScalar, elem: "string", ensure: ensure},
+		{name: "Namespace", kind: applyScalar, elem: "string", ensure: ensure},
+		{name: "UID", kind: applyScalar, elem: uidType, ensure: ensure},
+		{name: "ResourceVersion", kind: applyScalar, elem: "string", ensure: ensure},
+		{name: "Generation", kind: applyScalar, elem: "int64", ensure: ensure},
+		{name: "CreationTimestamp", kind: applyScalar, elem: timeType, ensure: ensure},
+		{name: "DeletionTimestamp", kind: applyScalar, elem: timeType, ensure: ensure},
+		{name: "DeletionGracePeriodSeconds", kind: applyScalar, elem: "int64", ensure: ensure},
+		{name: "Labels", kind: applyMap, typeStr: "map[string]string", ensure: ensure},
+		{name: "Annotations", kind: applyMap, typeStr: "map[string]string", ensure: ensure},
+		{name: "OwnerReferences", kind: applySlice, elem: ownerRef, elemIsConfig: true, ensure: ensure},
+		{name: "Finalizers", kind: applySlice, elem: "string", ensure: ensure},
+	}
+}
+
+// writeApplyFiles writes the apply configurations for a group-version into
+// the applyconfiguration package next to its types, along with the internal
+// package holding the schema.
+func writeApplyFiles(inputs []*ir.GroupVersion, typesDir string, out *applyWriter, internalOut *pkgWriter) error {
+	schemaYAML, schemaNames, err := buildApplySchema(inputs, out.CurrentGV, out.Packages, out.index)
+	if err != nil {
+		return err
+	}
+	out.schemaNames = schemaNames
+	out.ImportCurrent = true
+	out.PruneImports = true
+
+	applyDir := path.Join(typesDir, "applyconfiguration")
+	writeApplyConfigurations(inputs, out)
+	writeGoFile(path.Join(applyDir, "zz_generated.applyconfigurations.go"), out.pkgWriter, out.CurrentGV)
+
+	writeApplyInternal(schemaYAML, internalOut)
+	writeGoFile(path.Join(applyDir, "internal", "internal.go"), internalOut, out.CurrentGV)
+	return nil
+}
@@ -0,0 +1,763 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package tokgo
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gobuffalo/flect"
+
+	irt "k8s.io/idl/ckdl-ir/goir/types"
+)
+
+// NB(directxman12): this is what client-gen, lister-gen, and informer-gen
+// produce, except that each group-version gets its own self-contained
+// client & informer factory (instead of one clientset for everything), and
+// everything's configured with markers on the kind instead of +genclient
+// comments.
+
+// clientVerbs are the verbs typed clients can have, in the order they're
+// written.
+var clientVerbs = []string{
+	"create", "update", "update-status", "delete", "delete-collection", "get",
+	"list", "watch", "patch", "apply", "apply-status", "get-scale", "update-scale",
+}
+
+// clientKind is a persisted kind that gets a typed client.
+type clientKind struct {
+	// name is the kind's name in the IR
+	name     string
+	typeName string
+	// resource is the plural resource name
+	resource   string
+	namespaced bool
+	verbs      map[string]bool
+}
+
+// informable checks if the kind can have an informer & lister, which need
+// to be able to list & watch.
+func (k clientKind) informable() bool {
+	return k.verbs["list"] && k.verbs["watch"]
+}
+
+// plural is the Go name for a bunch of this kind (e.g. Widgets).
+func (k clientKind) plural() string {
+	return flect.Pluralize(k.typeName)
+}
+
+func lowerFirst(name string) string {
+	first, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(first)) + name[size:]
+}
+
+// clientKindFor works out the client for the given kind from its markers.
+// It returns false if the kind shouldn't get a client at all.
+func clientKindFor(kind *irt.Kind, applyConfigs bool) (clientKind, bool, error) {
+	if !kind.Object {
+		return clientKind{}, false, nil
+	}
+	typeName := nameType(kind.Name, kind.Attributes)
+	res := clientKind{
+		name:       kind.Name,
+		typeName:   typeName,
+		resource:   flect.Pluralize(strings.ToLower(typeName)),
+		namespaced: true,
+		verbs:      make(map[string]bool),
+	}
+
+	var marker *Client
+	for _, attr := range kind.Attributes {
+		switch {
+		case attr.MessageIs(&NoClient{}):
+			return clientKind{}, false, nil
+		case attr.MessageIs(&Client{}):
+			if marker != nil {
+				return clientKind{}, false, fmt.Errorf("more than one client marker on %s", kind.Name)
+			}
+			marker = &Client{}
+			if err := attr.UnmarshalTo(marker); err != nil {
+				return clientKind{}, false, fmt.Errorf("unable to decode client marker on %s: %w", kind.Name, err)
+			}
+		}
+	}
+
+	subresources := make(map[string]bool)
+	for _, field := range kind.Fields {
+		if field.Name == "status" {
+			subresources["status"] = true
+		}
+	}
+	if marker != nil {
+		switch marker.Scope {
+		case "", "Namespaced":
+		case "Cluster":
+			res.namespaced = false
+		default:
+			return clientKind{}, false, fmt.Errorf("client scope on %s must be %q or %q, not %q", kind.Name, "Namespaced", "Cluster", marker.Scope)
+		}
+		if marker.Resource != "" {
+			res.resource = marker.Resource
+		}
+		if len(marker.Subresources) > 0 {
+			subresources = make(map[string]bool)
+			for _, subresource := range marker.Subresources {
+				if subresource != "status" && subresource != "scale" {
+					return clientKind{}, false, fmt.Errorf("unknown subresource %q on %s, expected status or scale", subresource, kind.Name)
+				}
+				subresources[subresource] = true
+			}
+		}
+	}
+
+	available := make(map[string]bool)
+	for _, verb := range clientVerbs {
+		switch verb {
+		case "update-status":
+			available[verb] = subresources["status"]
+		case "apply":
+			available[verb] = applyConfigs
+		case "apply-status":
+			available[verb] = applyConfigs && subresources["status"]
+		case "get-scale", "update-scale":
+			available[verb] = subresources["scale"]
+		default:
+			available[verb] = true
+		}
+	}
+
+	if marker == nil || len(marker.Verbs) == 0 {
+		for verb, ok := range available {
+			res.verbs[verb] = ok
+		}
+	} else {
+		for _, verb := range marker.Verbs {
+			ok, known := available[verb]
+			if !known {
+				return clientKind{}, false, fmt.Errorf("unknown client verb %q on %s", verb, kind.Name)
+			}
+			if !ok {
+				// apply needs the apply configurations, and the subresource
+				// verbs need the subresource
+				return clientKind{}, false, fmt.Errorf("client verb %q isn't available for %s", verb, kind.Name)
+			}
+			res.verbs[verb] = true
+		}
+	}
+	if marker != nil {
+		for _, verb := range marker.SkipVerbs {
+			if _, known := available[verb]; !known {
+				return clientKind{}, false, fmt.Errorf("unknown client verb %q on %s", verb, kind.Name)
+			}
+			delete(res.verbs, verb)
+		}
+	}
+	return res, true, nil
+}
+
+// groupClientName is the name client-gen would give the client for the
+// given group-version (e.g. AppsV1 for apps/v1 or RbacV1 for
+// rbac.authorization.k8s.io/v1).
+func groupClientName(gv groupVersion) string {
+	group := strings.Split(gv.Group, ".")[0]
+	if group == "" {
+		group = "core"
+	}
+	var name strings.Builder
+	for _, part := range strings.Split(group, "-") {
+		name.WriteString(strings.Title(part))
+	}
+	return name.String() + strings.Title(gv.Version)
+}
+
+// clientWriter writes typed clients, listers, & informers, which each live
+// in their own package next to the types.
+type clientWriter struct {
+	*pkgWriter
+}
+
+func newClientWriter(w *pkgWriter) *clientWriter {
+	w.ImportCurrent = true
+	w.PruneImports = true
+	fmt.Fprint(w.header, "// Code generated by ckdl-to-tokgo. DO NOT EDIT.\n\n")
+	w.Package(comment{})
+	return &clientWriter{pkgWriter: w}
+}
+
+// apiRef refers to something declared in the types package, like a kind or
+// register.go's SchemeGroupVersion.
+func (w *clientWriter) apiRef(name string) string {
+	return w.MakeGVRef(typeKey{GroupVersion: w.CurrentGV, FullName: name}.Ref())
+}
+
+func (w *clientWriter) meta(name string) string {
+	return w.MakeGVRef(metaRef(name))
+}
+
+// sibling imports one of the other packages generated for this
+// group-version (e.g. listers from informers).
+func (w *clientWriter) sibling(dir, alias string) string {
+	return w.Import(w.Packages.For(w.CurrentGV).ImportPath+"/"+dir, alias)
+}
+
+func writeClient(kinds []clientKind, out *clientWriter) {
+	groupName := groupClientName(out.CurrentGV)
+	clientName := groupName + "Client"
+	rest := out.Import("k8s.io/client-go/rest", "rest")
+	runtime := out.Import("k8s.io/apimachinery/pkg/runtime", "runtime")
+	serializer := out.Import("k8s.io/apimachinery/pkg/runtime/serializer", "serializer")
+	schema := out.Import("k8s.io/apimachinery/pkg/runtime/schema", "schema")
+	utilruntime := out.Import("k8s.io/apimachinery/pkg/util/runtime", "utilruntime")
+	apiPath := "/apis"
+	if out.CurrentGV.Group == "" {
+		apiPath = "/api"
+	}
+
+	group := new(bytes.Buffer)
+	fmt.Fprintf(group, "// %sInterface has methods to work with %s/%s resources.\n", groupName, out.CurrentGV.Group, out.CurrentGV.Version)
+	fmt.Fprintf(group, "type %sInterface interface {\nRESTClient() %s.Interface\n", groupName, rest)
+	for _, kind := range kinds {
+		fmt.Fprintf(group, "%sGetter\n", kind.plural())
+	}
+	fmt.Fprint(group, "}\n\n")
+
+	fmt.Fprintf(group, "// %s is used to interact with features provided by the %s group.\n", clientName, out.CurrentGV.Group)
+	fmt.Fprintf(group, "type %s struct {\nrestClient %s.Interface\n}\n\n", clientName, rest)
+	for _, kind := range kinds {
+		if kind.namespaced {
+			fmt.Fprintf(group, "func (c *%s) %s(namespace string) %sInterface {\nreturn new%s(c, namespace)\n}\n\n", clientName, kind.plural(), kind.typeName, kind.plural())
+		} else {
+			fmt.Fprintf(group, "func (c *%s) %s() %sInterface {\nreturn new%s(c)\n}\n\n", clientName, kind.plural(), kind.typeName, kind.plural())
+		}
+	}
+
+	fmt.Fprintf(group, `// NewForConfig creates a new %[1]s for the given config.
+func NewForConfig(c *%[2]s.Config) (*%[1]s, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	client, err := %[2]s.RESTClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return &%[1]s{client}, nil
+}
+
+// NewForConfigOrDie creates a new %[1]s for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *%[2]s.Config) *%[1]s {
+	client, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// New creates a new %[1]s for the given RESTClient.
+func New(c %[2]s.Interface) *%[1]s {
+	return &%[1]s{c}
+}
+
+func setConfigDefaults(config *%[2]s.Config) error {
+	gv := %[3]s
+	config.GroupVersion = &gv
+	config.APIPath = %[4]q
+	config.NegotiatedSerializer = codecs.WithoutConversion()
+	if config.UserAgent == "" {
+		config.UserAgent = %[2]s.DefaultKubernetesUserAgent()
+	}
+	return nil
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *%[1]s) RESTClient() %[2]s.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
+
+`, clientName, rest, out.apiRef("SchemeGroupVersion"), apiPath)
+
+	// the scheme only needs this group-version's types, so we don't bother
+	// with a separate scheme package like client-gen does
+	fmt.Fprintf(group, "var (\nscheme = %[1]s.NewScheme()\ncodecs = %[2]s.NewCodecFactory(scheme)\nparameterCodec = %[1]s.NewParameterCodec(scheme)\n)\n\n", runtime, serializer)
+	fmt.Fprintf(group, "func init() {\n%s(scheme, %s.GroupVersion{Version: \"v1\"})\n", out.meta("AddToGroupVersion"), schema)
+	fmt.Fprintf(group, "%s.Must(%s(scheme))\n}\n", utilruntime, out.apiRef("AddToScheme"))
+	out.Code(clientName, group)
+
+	for _, kind := range kinds {
+		out.writeKindClient(kind, clientName)
+	}
+}
+
+func (w *clientWriter) writeKindClient(kind clientKind, clientName string) {
+	rest := w.Import("k8s.io/client-go/rest", "rest")
+	implName := lowerFirst(kind.plural())
+
+	out := new(bytes.Buffer)
+	fmt.Fprintf(out, "// %sGetter has a method to return a %sInterface.\n", kind.plural(), kind.typeName)
+	fmt.Fprintln(out, "// A group's client should implement this interface.")
+	if kind.namespaced {
+		fmt.Fprintf(out, "type %sGetter interface {\n%s(namespace string) %sInterface\n}\n\n", kind.plural(), kind.plural(), kind.typeName)
+	} else {
+		fmt.Fprintf(out, "type %sGetter interface {\n%s() %sInterface\n}\n\n", kind.plural(), kind.plural(), kind.typeName)
+	}
+
+	fmt.Fprintf(out, "// %sInterface has methods to work with %s resources.\n", kind.typeName, kind.typeName)
+	fmt.Fprintf(out, "type %sInterface interface {\n", kind.typeName)
+	for _, verb := range clientVerbs {
+		if kind.verbs[verb] {
+			fmt.Fprintln(out, w.verbSignature(kind, verb))
+		}
+	}
+	fmt.Fprint(out, "}\n\n")
+
+	fmt.Fprintf(out, "// %s implements %sInterface\n", implName, kind.typeName)
+	fmt.Fprintf(out, "type %s struct {\nclient %s.Interface\n", implName, rest)
+	if kind.namespaced {
+		fmt.Fprintln(out, "ns string")
+	}
+	fmt.Fprint(out, "}\n\n")
+
+	fmt.Fprintf(out, "// new%s returns a %s\n", kind.plural(), implName)
+	if kind.namespaced {
+		fmt.Fprintf(out, "func new%s(c *%s, namespace string) *%s {\nreturn &%s{\nclient: c.RESTClient(),\nns: namespace,\n}\n}\n\n", kind.plural(), clientName, implName, implName)
+	} else {
+		fmt.Fprintf(out, "func new%s(c *%s) *%s {\nreturn &%s{\nclient: c.RESTClient(),\n}\n}\n\n", kind.plural(), clientName, implName, implName)
+	}
+
+	for _, verb := range clientVerbs {
+		if kind.verbs[verb] {
+			w.writeVerb(out, kind, implName, verb)
+		}
+	}
+	w.Code(kind.typeName+"Interface", out)
+}
+
+// verbSignature returns the method signature for the given verb, as it
+// appears in the kind's client interface.
+func (w *clientWriter) verbSignature(kind clientKind, verb string) string {
+	ctx := w.Import("context", "context") + ".Context"
+	apiType := "*" + w.apiRef(kind.name)
+	switch verb {
+	case "create":
+		return fmt.Sprintf("Create(ctx %s, obj %s, opts %s) (%s, error)", ctx, apiType, w.meta("CreateOptions"), apiType)
+	case "update":
+		return fmt.Sprintf("Update(ctx %s, obj %s, opts %s) (%s, error)", ctx, apiType, w.meta("UpdateOptions"), apiType)
+	case "update-status":
+		return fmt.Sprintf("UpdateStatus(ctx %s, obj %s, opts %s) (%s, error)", ctx, apiType, w.meta("UpdateOptions"), apiType)
+	case "delete":
+		return fmt.Sprintf("Delete(ctx %s, name string, opts %s) error", ctx, w.meta("DeleteOptions"))
+	case "delete-collection":
+		return fmt.Sprintf("DeleteCollection(ctx %s, opts %s, listOpts %s) error", ctx, w.meta("DeleteOptions"), w.meta("ListOptions"))
+	case "get":
+		return fmt.Sprintf("Get(ctx %s, name string, opts %s) (%s, error)", ctx, w.meta("GetOptions"), apiType)
+	case "list":
+		return fmt.Sprintf("List(ctx %s, opts %s) (*%sList, error)", ctx, w.meta("ListOptions"), w.apiRef(kind.name))
+	case "watch":
+		watch := w.Import("k8s.io/apimachinery/pkg/watch", "watch")
+		return fmt.Sprintf("Watch(ctx %s, opts %s) (%s.Interface, error)", ctx, w.meta("ListOptions"), watch)
+	case "patch":
+		types := w.Import("k8s.io/apimachinery/pkg/types", "types")
+		return fmt.Sprintf("Patch(ctx %s, name string, pt %s.PatchType, data []byte, opts %s, subresources ...string) (%s, error)", ctx, types, w.meta("PatchOptions"), apiType)
+	case "apply", "apply-status":
+		applyConfig := w.Import(applyPackage(w.Packages.For(w.CurrentGV)), "applyconfiguration") + "." + kind.typeName + "ApplyConfiguration"
+		method := "Apply"
+		if verb == "apply-status" {
+			method = "ApplyStatus"
+		}
+		return fmt.Sprintf("%s(ctx %s, obj *%s, opts %s) (%s, error)", method, ctx, applyConfig, w.meta("ApplyOptions"), apiType)
+	case "get-scale":
+		scale := w.Import("k8s.io/api/autoscaling/v1", "autoscalingv1") + ".Scale"
+		return fmt.Sprintf("GetScale(ctx %s, name string, opts %s) (*%s, error)", ctx, w.meta("GetOptions"), scale)
+	case "update-scale":
+		scale := w.Import("k8s.io/api/autoscaling/v1", "autoscalingv1") + ".Scale"
+		return fmt.Sprintf("UpdateScale(ctx %s, name string, scale *%s, opts %s) (*%s, error)", ctx, scale, w.meta("UpdateOptions"), scale)
+	default:
+		panic(fmt.Sprintf("unreachable: unknown client verb %q", verb))
+	}
+}
+
+// writeRequest writes the start of a REST request against the kind's
+// resource, followed by the given builder calls (each of which ends up on
+// its own line, like client-gen's output).
+func writeRequest(out *bytes.Buffer, kind clientKind, method string, calls ...string) {
+	fmt.Fprintf(out, "c.client.%s.\n", method)
+	if kind.namespaced {
+		fmt.Fprintln(out, "Namespace(c.ns).")
+	}
+	fmt.Fprintf(out, "Resource(%q)", kind.resource)
+	for _, call := range calls {
+		fmt.Fprintf(out, ".\n%s", call)
+	}
+	fmt.Fprintln(out, "")
+}
+
+func (w *clientWriter) writeVerb(out *bytes.Buffer, kind clientKind, implName, verb string) {
+	signature := w.verbSignature(kind, verb)
+	method := signature[:strings.Index(signature, "(")]
+	apiType := w.apiRef(kind.name)
+	timeout := func(opts string) {
+		fmt.Fprintf(out, "var timeout %s.Duration\n", w.Import("time", "time"))
+		fmt.Fprintf(out, "if %[1]s.TimeoutSeconds != nil {\ntimeout = %[2]s.Duration(*%[1]s.TimeoutSeconds) * %[2]s.Second\n}\n", opts, w.Import("time", "time"))
+	}
+	// name the results so that the body can just assign to them
+	resultSig := func(resultType string) string {
+		return signature[:strings.LastIndex(signature, "(")] + fmt.Sprintf("(result %s, err error)", resultType)
+	}
+
+	switch verb {
+	case "create":
+		fmt.Fprintf(out, "// Create takes the representation of a %s and creates it.  Returns the server's representation of the %s, and an error, if there is any.\n", kind.typeName, kind.typeName)
+		fmt.Fprintf(out, "func (c *%s) %s {\nresult = &%s{}\nerr = ", implName, resultSig("*"+apiType), apiType)
+		writeRequest(out, kind, "Post()", "VersionedParams(&opts, parameterCodec)", "Body(obj)", "Do(ctx)", "Into(result)")
+	case "update", "update-status":
+		if verb == "update" {
+			fmt.Fprintf(out, "// Update takes the representation of a %s and updates it. Returns the server's representation of the %s, and an error, if there is any.\n", kind.typeName, kind.typeName)
+			fmt.Fprintf(out, "func (c *%s) %s {\nresult = &%s{}\nerr = ", implName, resultSig("*"+apiType), apiType)
+			writeRequest(out, kind, "Put()", "Name(obj.Name)", "VersionedParams(&opts, parameterCodec)", "Body(obj)", "Do(ctx)", "Into(result)")
+		} else {
+			fmt.Fprintln(out, "// UpdateStatus was generated because the type contains a Status member.")
+			fmt.Fprintf(out, "func (c *%s) %s {\nresult = &%s{}\nerr = ", implName, resultSig("*"+apiType), apiType)
+			writeRequest(out, kind, "Put()", "Name(obj.Name)", `SubResource("status")`, "VersionedParams(&opts, parameterCodec)", "Body(obj)", "Do(ctx)", "Into(result)")
+		}
+	case "delete":
+		fmt.Fprintf(out, "// Delete takes name of the %s and deletes it. Returns an error if one occurs.\n", kind.typeName)
+		fmt.Fprintf(out, "func (c *%s) %s {\nreturn ", implName, signature)
+		writeRequest(out, kind, "Delete()", "Name(name)", "Body(&opts)", "Do(ctx)", "Error()")
+		fmt.Fprint(out, "}\n\n")
+		return
+	case "delete-collection":
+		fmt.Fprintln(out, "// DeleteCollection deletes a collection of objects.")
+		fmt.Fprintf(out, "func (c *%s) %s {\n", implName, signature)
+		timeout("listOpts")
+		fmt.Fprint(out, "return ")
+		writeRequest(out, kind, "Delete()", "VersionedParams(&listOpts, parameterCodec)", "Timeout(timeout)", "Body(&opts)", "Do(ctx)", "Error()")
+		fmt.Fprint(out, "}\n\n")
+		return
+	case "get":
+		fmt.Fprintf(out, "// Get takes name of the %s, and returns the corresponding %s object, and an error if there is any.\n", kind.typeName, kind.typeName)
+		fmt.Fprintf(out, "func (c *%s) %s {\nresult = &%s{}\nerr = ", implName, resultSig("*"+apiType), apiType)
+		writeRequest(out, kind, "Get()", "Name(name)", "VersionedParams(&opts, parameterCodec)", "Do(ctx)", "Into(result)")
+	case "list":
+		fmt.Fprintf(out, "// List takes label and field selectors, and returns the list of %s that match those selectors.\n", kind.plural())
+		fmt.Fprintf(out, "func (c *%s) %s {\n", implName, resultSig("*"+apiType+"List"))
+		timeout("opts")
+		fmt.Fprintf(out, "result = &%sList{}\nerr = ", apiType)
+		writeRequest(out, kind, "Get()", "VersionedParams(&opts, parameterCodec)", "Timeout(timeout)", "Do(ctx)", "Into(result)")
+	case "watch":
+		fmt.Fprintf(out, "// Watch returns a watch.Interface that watches the requested %s.\n", lowerFirst(kind.plural()))
+		fmt.Fprintf(out, "func (c *%s) %s {\n", implName, signature)
+		timeout("opts")
+		fmt.Fprint(out, "opts.Watch = true\nreturn ")
+		writeRequest(out, kind, "Get()", "VersionedParams(&opts, parameterCodec)", "Timeout(timeout)", "Watch(ctx)")
+		fmt.Fprint(out, "}\n\n")
+		return
+	case "patch":
+		fmt.Fprintf(out, "// Patch applies the patch and returns the patched %s.\n", lowerFirst(kind.typeName))
+		fmt.Fprintf(out, "func (c *%s) %s {\nresult = &%s{}\nerr = ", implName, resultSig("*"+apiType), apiType)
+		writeRequest(out, kind, "Patch(pt)", "Name(name)", "SubResource(subresources...)", "VersionedParams(&opts, parameterCodec)", "Body(data)", "Do(ctx)", "Into(result)")
+	case "apply", "apply-status":
+		fmtPkg := w.Import("fmt", "fmt")
+		types := w.Import("k8s.io/apimachinery/pkg/types", "types")
+		if verb == "apply" {
+			fmt.Fprintf(out, "// Apply takes the given apply declarative configuration, applies it and returns the applied %s.\n", lowerFirst(kind.typeName))
+		} else {
+			fmt.Fprintln(out, "// ApplyStatus was generated because the type contains a Status member.")
+		}
+		fmt.Fprintf(out, "func (c *%s) %s {\n", implName, resultSig("*"+apiType))
+		fmt.Fprintf(out, "if obj == nil {\nreturn nil, %s.Errorf(\"%s provided to %s must not be nil\")\n}\n", fmtPkg, lowerFirst(kind.typeName), method)
+		fmt.Fprintf(out, "patchOpts := opts.ToPatchOptions()\ndata, err := %s.Marshal(obj)\nif err != nil {\nreturn nil, err\n}\n", w.Import("encoding/json", "json"))
+		fmt.Fprintf(out, "name := obj.Name\nif name == nil {\nreturn nil, %s.Errorf(\"%s.Name must be provided to %s\")\n}\n", fmtPkg, lowerFirst(kind.typeName), method)
+		fmt.Fprintf(out, "result = &%s{}\nerr = ", apiType)
+		calls := []string{"Name(*name)"}
+		if verb == "apply-status" {
+			calls = append(calls, `SubResource("status")`)
+		}
+		calls = append(calls, "VersionedParams(&patchOpts, parameterCodec)", "Body(data)", "Do(ctx)", "Into(result)")
+		writeRequest(out, kind, fmt.Sprintf("Patch(%s.ApplyPatchType)", types), calls...)
+	case "get-scale":
+		scale := w.Import("k8s.io/api/autoscaling/v1", "autoscalingv1") + ".Scale"
+		fmt.Fprintf(out, "// GetScale takes name of the %s, and returns the corresponding Scale object, and an error if there is any.\n", lowerFirst(kind.typeName))
+		fmt.Fprintf(out, "func (c *%s) %s {\nresult = &%s{}\nerr = ", implName, resultSig("*"+scale), scale)
+		writeRequest(out, kind, "Get()", "Name(name)", `SubResource("scale")`, "VersionedParams(&opts, parameterCodec)", "Do(ctx)", "Into(result)")
+	case "update-scale":
+		scale := w.Import("k8s.io/api/autoscaling/v1", "autoscalingv1") + ".Scale"
+		fmt.Fprintf(out, "// UpdateScale takes the top resource name and the representation of a scale and updates it. Returns the server's representation of the scale, and an error, if there is any.\n")
+		fmt.Fprintf(out, "func (c *%s) %s {\nresult = &%s{}\nerr = ", implName, resultSig("*"+scale), scale)
+		writeRequest(out, kind, "Put()", "Name(name)", `SubResource("scale")`, "VersionedParams(&opts, parameterCodec)", "Body(scale)", "Do(ctx)", "Into(result)")
+	}
+	fmt.Fprint(out, "return\n}\n\n")
+}
+
+func writeListers(kinds []clientKind, out *clientWriter) {
+	cache := out.Import("k8s.io/client-go/tools/cache", "cache")
+	labels := out.Import("k8s.io/apimachinery/pkg/labels", "labels")
+	errors := out.Import("k8s.io/apimachinery/pkg/api/errors", "errors")
+
+	for _, kind := range kinds {
+		if !kind.informable() {
+			continue
+		}
+		apiType := out.apiRef(kind.name)
+		listerName := kind.typeName + "Lister"
+		implName := lowerFirst(listerName)
+		notFound := fmt.Sprintf("%s.NewNotFound(%s(%q), name)", errors, out.apiRef("Resource"), strings.ToLower(kind.typeName))
+
+		code := new(bytes.Buffer)
+		fmt.Fprintf(code, "// %s helps list %s.\n// All objects returned here must be treated as read-only.\n", listerName, kind.plural())
+		fmt.Fprintf(code, "type %s interface {\n", listerName)
+		fmt.Fprintf(code, "// List lists all %s in the indexer.\n// Objects returned here must be treated as read-only.\n", kind.plural())
+		fmt.Fprintf(code, "List(selector %s.Selector) (ret []*%s, err error)\n", labels, apiType)
+		if kind.namespaced {
+			fmt.Fprintf(code, "// %[1]s returns an object that can list and get %[1]s.\n%[1]s(namespace string) %[2]sNamespaceLister\n", kind.plural(), kind.typeName)
+		} else {
+			fmt.Fprintf(code, "// Get retrieves the %s from the index for a given name.\n// Objects returned here must be treated as read-only.\n", kind.typeName)
+			fmt.Fprintf(code, "Get(name string) (*%s, error)\n", apiType)
+		}
+		fmt.Fprint(code, "}\n\n")
+
+		fmt.Fprintf(code, "// %s implements the %s interface.\ntype %s struct {\nindexer %s.Indexer\n}\n\n", implName, listerName, implName, cache)
+		fmt.Fprintf(code, "// New%s returns a new %s.\nfunc New%s(indexer %s.Indexer) %s {\nreturn &%s{indexer: indexer}\n}\n\n", listerName, listerName, listerName, cache, listerName, implName)
+
+		fmt.Fprintf(code, "// List lists all %s in the indexer.\n", kind.plural())
+		fmt.Fprintf(code, "func (s *%s) List(selector %s.Selector) (ret []*%s, err error) {\n", implName, labels, apiType)
+		fmt.Fprintf(code, "err = %s.ListAll(s.indexer, selector, func(m interface{}) {\nret = append(ret, m.(*%s))\n})\nreturn ret, err\n}\n\n", cache, apiType)
+
+		if !kind.namespaced {
+			fmt.Fprintf(code, "// Get retrieves the %s from the index for a given name.\n", kind.typeName)
+			fmt.Fprintf(code, "func (s *%s) Get(name string) (*%s, error) {\n", implName, apiType)
+			fmt.Fprintf(code, "obj, exists, err := s.indexer.GetByKey(name)\nif err != nil {\nreturn nil, err\n}\n")
+			fmt.Fprintf(code, "if !exists {\nreturn nil, %s\n}\nreturn obj.(*%s), nil\n}\n", notFound, apiType)
+			out.Code(listerName, code)
+			continue
+		}
+
+		nsListerName := kind.typeName + "NamespaceLister"
+		nsImplName := lowerFirst(nsListerName)
+		fmt.Fprintf(code, "// %s returns an object that can list and get %s.\n", kind.plural(), kind.plural())
+		fmt.Fprintf(code, "func (s *%s) %s(namespace string) %s {\nreturn %s{indexer: s.indexer, namespace: namespace}\n}\n\n", implName, kind.plural(), nsListerName, nsImplName)
+
+		fmt.Fprintf(code, "// %s helps list and get %s.\n// All objects returned here must be treated as read-only.\n", nsListerName, kind.plural())
+		fmt.Fprintf(code, "type %s interface {\n", nsListerName)
+		fmt.Fprintf(code, "// List lists all %s in the indexer for a given namespace.\n// Objects returned here must be treated as read-only.\n", kind.plural())
+		fmt.Fprintf(code, "List(selector %s.Selector) (ret []*%s, err error)\n", labels, apiType)
+		fmt.Fprintf(code, "// Get retrieves the %s from the indexer for a given namespace and name.\n// Objects returned here must be treated as read-only.\n", kind.typeName)
+		fmt.Fprintf(code, "Get(name string) (*%s, error)\n}\n\n", apiType)
+
+		fmt.Fprintf(code, "// %s implements the %s\n// interface.\ntype %s struct {\nindexer %s.Indexer\nnamespace string\n}\n\n", nsImplName, nsListerName, nsImplName, cache)
+		fmt.Fprintf(code, "// List lists all %s in the indexer for a given namespace.\n", kind.plural())
+		fmt.Fprintf(code, "func (s %s) List(selector %s.Selector) (ret []*%s, err error) {\n", nsImplName, labels, apiType)
+		fmt.Fprintf(code, "err = %s.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {\nret = append(ret, m.(*%s))\n})\nreturn ret, err\n}\n\n", cache, apiType)
+		fmt.Fprintf(code, "// Get retrieves the %s from the indexer for a given namespace and name.\n", kind.typeName)
+		fmt.Fprintf(code, "func (s %s) Get(name string) (*%s, error) {\n", nsImplName, apiType)
+		fmt.Fprintf(code, "obj, exists, err := s.indexer.GetByKey(s.namespace + \"/\" + name)\nif err != nil {\nreturn nil, err\n}\n")
+		fmt.Fprintf(code, "if !exists {\nreturn nil, %s\n}\nreturn obj.(*%s), nil\n}\n", notFound, apiType)
+		out.Code(listerName, code)
+	}
+}
+
+func writeInformers(kinds []clientKind, out *clientWriter) {
+	cache := out.Import("k8s.io/client-go/tools/cache", "cache")
+	runtime := out.Import("k8s.io/apimachinery/pkg/runtime", "runtime")
+	timePkg := out.Import("time", "time")
+	reflect := out.Import("reflect", "reflect")
+	sync := out.Import("sync", "sync")
+	client := out.sibling("client", "typedclient") + "." + groupClientName(out.CurrentGV) + "Interface"
+
+	factory := new(bytes.Buffer)
+	fmt.Fprintf(factory, "// TweakListOptionsFunc modifies the options used to list & watch objects.\ntype TweakListOptionsFunc func(*%s)\n\n", out.meta("ListOptions"))
+	fmt.Fprintf(factory, "// NewInformerFunc constructs the informer for a kind using the given client.\ntype NewInformerFunc func(%s, %s.Duration) %s.SharedIndexInformer\n\n", client, timePkg, cache)
+
+	fmt.Fprintf(factory, "// SharedInformerFactory provides shared informers for the kinds in %s/%s.\n", out.CurrentGV.Group, out.CurrentGV.Version)
+	fmt.Fprintf(factory, `type SharedInformerFactory interface {
+	// Start initializes all requested informers.
+	Start(stopCh <-chan struct{})
+	// WaitForCacheSync waits for all started informers' cache were synced.
+	WaitForCacheSync(stopCh <-chan struct{}) map[%[1]s.Type]bool
+	// InformerFor returns the informer for obj, constructing it with newFunc
+	// if it doesn't exist yet.
+	InformerFor(obj %[2]s.Object, newFunc NewInformerFunc) %[3]s.SharedIndexInformer
+
+`, reflect, runtime, cache)
+	for _, kind := range kinds {
+		if kind.informable() {
+			fmt.Fprintf(factory, "%s() %sInformer\n", kind.plural(), kind.typeName)
+		}
+	}
+	fmt.Fprint(factory, "}\n\n")
+
+	fmt.Fprintf(factory, `type sharedInformerFactory struct {
+	client           %[1]s
+	namespace        string
+	tweakListOptions TweakListOptionsFunc
+	defaultResync    %[2]s.Duration
+
+	lock      %[3]s.Mutex
+	informers map[%[4]s.Type]%[5]s.SharedIndexInformer
+	// startedInformers is used for tracking which informers have been started.
+	// This allows Start() to be called multiple times safely.
+	startedInformers map[%[4]s.Type]bool
+}
+
+// NewSharedInformerFactory constructs a new instance of SharedInformerFactory for all namespaces.
+func NewSharedInformerFactory(client %[1]s, defaultResync %[2]s.Duration) SharedInformerFactory {
+	return NewFilteredSharedInformerFactory(client, defaultResync, %[6]s, nil)
+}
+
+// NewFilteredSharedInformerFactory constructs a new instance of SharedInformerFactory.
+// Listers obtained via this SharedInformerFactory will be subject to the same filters
+// as specified here.
+func NewFilteredSharedInformerFactory(client %[1]s, defaultResync %[2]s.Duration, namespace string, tweakListOptions TweakListOptionsFunc) SharedInformerFactory {
+	return &sharedInformerFactory{
+		client:           client,
+		namespace:        namespace,
+		tweakListOptions: tweakListOptions,
+		defaultResync:    defaultResync,
+		informers:        make(map[%[4]s.Type]%[5]s.SharedIndexInformer),
+		startedInformers: make(map[%[4]s.Type]bool),
+	}
+}
+
+// Start initializes all requested informers.
+func (f *sharedInformerFactory) Start(stopCh <-chan struct{}) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for informerType, informer := range f.informers {
+		if !f.startedInformers[informerType] {
+			go informer.Run(stopCh)
+			f.startedInformers[informerType] = true
+		}
+	}
+}
+
+// WaitForCacheSync waits for all started informers' cache were synced.
+func (f *sharedInformerFactory) WaitForCacheSync(stopCh <-chan struct{}) map[%[4]s.Type]bool {
+	informers := func() map[%[4]s.Type]%[5]s.SharedIndexInformer {
+		f.lock.Lock()
+		defer f.lock.Unlock()
+
+		informers := map[%[4]s.Type]%[5]s.SharedIndexInformer{}
+		for informerType, informer := range f.informers {
+			if f.startedInformers[informerType] {
+				informers[informerType] = informer
+			}
+		}
+		return informers
+	}()
+
+	res := map[%[4]s.Type]bool{}
+	for informType, informer := range informers {
+		res[informType] = %[5]s.WaitForCacheSync(stopCh, informer.HasSynced)
+	}
+	return res
+}
+
+// InformerFor returns the SharedIndexInformer for obj using an internal
+// client.
+func (f *sharedInformerFactory) InformerFor(obj %[7]s.Object, newFunc NewInformerFunc) %[5]s.SharedIndexInformer {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	informerType := %[4]s.TypeOf(obj)
+	informer, exists := f.informers[informerType]
+	if exists {
+		return informer
+	}
+	informer = newFunc(f.client, f.defaultResync)
+	f.informers[informerType] = informer
+	return informer
+}
+
+`, client, timePkg, sync, reflect, cache, out.meta("NamespaceAll"), runtime)
+	for _, kind := range kinds {
+		if kind.informable() {
+			fmt.Fprintf(factory, "func (f *sharedInformerFactory) %s() %sInformer {\n", kind.plural(), kind.typeName)
+			fmt.Fprintf(factory, "return &%sInformer{factory: f, namespace: f.namespace, tweakListOptions: f.tweakListOptions}\n}\n\n", lowerFirst(kind.typeName))
+		}
+	}
+	out.Code("SharedInformerFactory", factory)
+
+	for _, kind := range kinds {
+		if kind.informable() {
+			out.writeInformer(kind, client)
+		}
+	}
+}
+
+func (w *clientWriter) writeInformer(kind clientKind, client string) {
+	cache := w.Import("k8s.io/client-go/tools/cache", "cache")
+	runtime := w.Import("k8s.io/apimachinery/pkg/runtime", "runtime")
+	watch := w.Import("k8s.io/apimachinery/pkg/watch", "watch")
+	context := w.Import("context", "context")
+	timePkg := w.Import("time", "time")
+	listers := w.sibling("listers", "listers")
+	apiType := w.apiRef(kind.name)
+	informerName := kind.typeName + "Informer"
+	implName := lowerFirst(informerName)
+
+	// cluster-scoped kinds just ignore the namespace
+	nsParam, nsCallArg, nsArg, getterArg, indexers := "", "", "", "", cache+".Indexers{}"
+	if kind.namespaced {
+		nsParam, nsCallArg, nsArg, getterArg = "namespace string, ", "namespace, ", "f.namespace, ", "namespace"
+		indexers = fmt.Sprintf("%[1]s.Indexers{%[1]s.NamespaceIndex: %[1]s.MetaNamespaceIndexFunc}", cache)
+	}
+
+	code := new(bytes.Buffer)
+	fmt.Fprintf(code, "// %s provides access to a shared informer and lister for\n// %s.\n", informerName, kind.plural())
+	fmt.Fprintf(code, "type %s interface {\nInformer() %s.SharedIndexInformer\nLister() %s.%sLister\n}\n\n", informerName, cache, listers, kind.typeName)
+	fmt.Fprintf(code, "type %s struct {\nfactory SharedInformerFactory\ntweakListOptions TweakListOptionsFunc\nnamespace string\n}\n\n", implName)
+
+	fmt.Fprintf(code, "// New%[1]s constructs a new informer for %[2]s type.\n// Always prefer using an informer factory to get a shared informer instead of getting an independent\n// one. This reduces memory footprint and number of connections to the server.\n", informerName, kind.typeName)
+	fmt.Fprintf(code, "func New%s(client %s, %sresyncPeriod %s.Duration, indexers %s.Indexers) %s.SharedIndexInformer {\n", informerName, client, nsParam, timePkg, cache, cache)
+	fmt.Fprintf(code, "return NewFiltered%s(client, %sresyncPeriod, indexers, nil)\n}\n\n", informerName, nsCallArg)
+
+	fmt.Fprintf(code, "// NewFiltered%[1]s constructs a new informer for %[2]s type.\n// Always prefer using an informer factory to get a shared informer instead of getting an independent\n// one. This reduces memory footprint and number of connections to the server.\n", informerName, kind.typeName)
+	fmt.Fprintf(code, "func NewFiltered%s(client %s, %sresyncPeriod %s.Duration, indexers %s.Indexers, tweakListOptions TweakListOptionsFunc) %s.SharedIndexInformer {\n", informerName, client, nsParam, timePkg, cache, cache)
+	fmt.Fprintf(code, "return %s.NewSharedIndexInformer(\n&%s.ListWatch{\n", cache, cache)
+	fmt.Fprintf(code, "ListFunc: func(options %s) (%s.Object, error) {\nif tweakListOptions != nil {\ntweakListOptions(&options)\n}\n", w.meta("ListOptions"), runtime)
+	fmt.Fprintf(code, "return client.%s(%s).List(%s.TODO(), options)\n},\n", kind.plural(), getterArg, context)
+	fmt.Fprintf(code, "WatchFunc: func(options %s) (%s.Interface, error) {\nif tweakListOptions != nil {\ntweakListOptions(&options)\n}\n", w.meta("ListOptions"), watch)
+	fmt.Fprintf(code, "return client.%s(%s).Watch(%s.TODO(), options)\n},\n},\n", kind.plural(), getterArg, context)
+	fmt.Fprintf(code, "&%s{},\nresyncPeriod,\nindexers,\n)\n}\n\n", apiType)
+
+	fmt.Fprintf(code, "func (f *%s) defaultInformer(client %s, resyncPeriod %s.Duration) %s.SharedIndexInformer {\n", implName, client, timePkg, cache)
+	fmt.Fprintf(code, "return NewFiltered%s(client, %sresyncPeriod, %s, f.tweakListOptions)\n}\n\n", informerName, nsArg, indexers)
+	fmt.Fprintf(code, "func (f *%s) Informer() %s.SharedIndexInformer {\nreturn f.factory.InformerFor(&%s{}, f.defaultInformer)\n}\n\n", implName, cache, apiType)
+	fmt.Fprintf(code, "func (f *%s) Lister() %s.%sLister {\nreturn %s.New%sLister(f.Informer().GetIndexer())\n}\n", implName, listers, kind.typeName, listers, kind.typeName)
+	w.Code(informerName, code)
+}
+
+// writeClientFiles writes the typed client, listers, & informers for the
+// persisted kinds in a group-version into packages next to its types.
+func writeClientFiles(kinds []clientKind, typesDir string, newWriter func() *clientWriter) {
+	if len(kinds) == 0 {
+		return
+	}
+	clientOut := newWriter()
+	writeClient(kinds, clientOut)
+	writeGoFile(path.Join(typesDir, "client", "zz_generated.client.go"), clientOut.pkgWriter, clientOut.CurrentGV)
+
+	informable := false
+	for _, kind := range kinds {
+		informable = informable || kind.informable()
+	}
+	if !informable {
+		return
+	}
+	listersOut := newWriter()
+	writeListers(kinds, listersOut)
+	writeGoFile(path.Join(typesDir, "listers", "zz_generated.listers.go"), listersOut.pkgWriter, listersOut.CurrentGV)
+
+	informersOut := newWriter()
+	writeInformers(kinds, informersOut)
+	writeGoFile(path.Join(typesDir, "informers", "zz_generated.informers.go"), informersOut.pkgWriter, informersOut.CurrentGV)
+}
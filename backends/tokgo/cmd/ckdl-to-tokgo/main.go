@@ -0,0 +1,14 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+
+// Command ckdl-to-tokgo runs the tokgo backend as its own process.
+package main
+
+import (
+	"k8s.io/idl/backends/common/backend"
+	"k8s.io/idl/backends/tokgo"
+)
+
+func main() {
+	backend.Main(tokgo.Backend)
+}
@@ -0,0 +1,420 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package tokgo
+
+import (
+	"bytes"
+	"fmt"
+
+	ir "k8s.io/idl/ckdl-ir/goir"
+	irt "k8s.io/idl/ckdl-ir/goir/types"
+)
+
+// NB(directxman12): unlike deepcopy-gen & controller-gen, we don't need to
+// look at the Go types to figure out how to copy things -- the IR already
+// tells us what's a list, map, reference, etc.  We just need to be careful
+// to produce the same Go types that the types file does.
+
+// copyKind describes how a value of some Go type gets copied.
+type copyKind int
+
+const (
+	// copyShallow types can be copied with plain assignment
+	copyShallow copyKind = iota
+	// copyDeep types have a DeepCopyInto method
+	copyDeep
+	// copyPtr, copySlice, and copyMap are unnamed containers of some other type
+	copyPtr
+	copySlice
+	copyMap
+)
+
+// goShape is the information needed to copy a value of a particular Go type.
+type goShape struct {
+	kind copyKind
+	// typeStr is the Go type, as written in the types file
+	typeStr string
+	// elem is the pointee, item, or value type for pointers, slices, & maps
+	elem *goShape
+	// nilable types with DeepCopyInto are named slices & maps, which
+	// need a nil check so that we don't turn nil into empty
+	nilable bool
+}
+
+func shallow(typeStr string) *goShape {
+	return &goShape{kind: copyShallow, typeStr: typeStr}
+}
+func deep(typeStr string) *goShape {
+	return &goShape{kind: copyDeep, typeStr: typeStr}
+}
+func ptrTo(elem *goShape) *goShape {
+	return &goShape{kind: copyPtr, typeStr: "*" + elem.typeStr, elem: elem}
+}
+func sliceOf(elem *goShape) *goShape {
+	return &goShape{kind: copySlice, typeStr: "[]" + elem.typeStr, elem: elem}
+}
+
+func (idx *typeIndex) refShape(ref *irt.Reference, refs refMaker) *goShape {
+	typeStr := refs.MakeGVRef(ref)
+	key := keyFor(ref)
+	if _, isKind := idx.Kinds[key]; isKind {
+		return deep(typeStr)
+	}
+	subtype, known := idx.Subtypes[key]
+	if !known {
+		// the types we synthesize for primitives, or something generated
+		// elsewhere (which we assume has deepcopy functions too)
+		switch {
+		case sameRef(ref, durationRef), sameRef(ref, intStrRef):
+			return shallow(typeStr)
+		default:
+			return deep(typeStr)
+		}
+	}
+	switch body := subtype.Type.(type) {
+	case *irt.Subtype_Enum:
+		return shallow(typeStr)
+	case *irt.Subtype_PrimitiveAlias:
+		switch primShape(body.PrimitiveAlias, refs).kind {
+		case copyShallow:
+			return shallow(typeStr)
+		case copySlice:
+			return &goShape{kind: copyDeep, typeStr: typeStr, nilable: true}
+		default:
+			return deep(typeStr)
+		}
+	case *irt.Subtype_ReferenceAlias:
+		underlying := idx.refShape(body.ReferenceAlias, refs)
+		if underlying.kind == copyShallow {
+			return shallow(typeStr)
+		}
+		return &goShape{kind: copyDeep, typeStr: typeStr, nilable: underlying.nilable}
+	case *irt.Subtype_Struct, *irt.Subtype_Union:
+		return deep(typeStr)
+	default:
+		// named containers get DeepCopyInto too, but can be nil
+		return &goShape{kind: copyDeep, typeStr: typeStr, nilable: true}
+	}
+}
+
+func primShape(prim *irt.Primitive, refs refMaker) *goShape {
+	typeStr := primType(prim, false, refs)
+	switch prim.Type {
+	case irt.Primitive_TIME, irt.Primitive_QUANTITY:
+		return deep(typeStr)
+	case irt.Primitive_BYTES:
+		return sliceOf(shallow("byte"))
+	default:
+		return shallow(typeStr)
+	}
+}
+
+func (idx *typeIndex) listShape(list *irt.List, refs refMaker) *goShape {
+	switch items := list.Items.(type) {
+	case *irt.List_Primitive:
+		return sliceOf(primShape(items.Primitive, refs))
+	case *irt.List_Reference:
+		return sliceOf(idx.refShape(items.Reference, refs))
+	default:
+		panic(fmt.Sprintf("unreachable: unknown list items type %T", items))
+	}
+}
+
+func (idx *typeIndex) setShape(set *irt.Set, refs refMaker) *goShape {
+	switch items := set.Items.(type) {
+	case *irt.Set_Primitive:
+		return sliceOf(primShape(items.Primitive, refs))
+	case *irt.Set_Reference:
+		return sliceOf(idx.refShape(items.Reference, refs))
+	default:
+		panic(fmt.Sprintf("unreachable: unknown set items type %T", items))
+	}
+}
+
+func (idx *typeIndex) primMapShape(primMap *irt.PrimitiveMap, refs refMaker) *goShape {
+	var key *goShape
+	switch keyType := primMap.Key.(type) {
+	case *irt.PrimitiveMap_PrimitiveKey:
+		key = primShape(keyType.PrimitiveKey, refs)
+	case *irt.PrimitiveMap_ReferenceKey:
+		key = idx.refShape(keyType.ReferenceKey, refs)
+	default:
+		panic(fmt.Sprintf("unreachable: unknown simple-map key type %T", keyType))
+	}
+
+	var val *goShape
+	switch valType := primMap.Value.(type) {
+	case *irt.PrimitiveMap_PrimitiveValue:
+		val = primShape(valType.PrimitiveValue, refs)
+	case *irt.PrimitiveMap_ReferenceValue:
+		val = idx.refShape(valType.ReferenceValue, refs)
+	case *irt.PrimitiveMap_SimpleListValue:
+		val = idx.listShape(valType.SimpleListValue, refs)
+	default:
+		panic(fmt.Sprintf("unreachable: unknown simple-map value type %T", valType))
+	}
+
+	return &goShape{
+		kind:    copyMap,
+		typeStr: fmt.Sprintf("map[%s]%s", key.typeStr, val.typeStr),
+		elem:    val,
+	}
+}
+
+// fieldShape mirrors writeField's choice of Go type for a field.
+func (idx *typeIndex) fieldShape(field *irt.Field, refs refMaker) *goShape {
+	switch typ := field.Type.(type) {
+	case *irt.Field_Primitive:
+		shape := primShape(typ.Primitive, refs)
+		if isPointerField(idx.Graph, field) {
+			return ptrTo(shape)
+		}
+		return shape
+	case *irt.Field_NamedType:
+		shape := idx.refShape(typ.NamedType, refs)
+		if isPointerField(idx.Graph, field) {
+			return ptrTo(shape)
+		}
+		return shape
+	case *irt.Field_Set:
+		return idx.setShape(typ.Set, refs)
+	case *irt.Field_List:
+		return idx.listShape(typ.List, refs)
+	case *irt.Field_PrimitiveMap:
+		return idx.primMapShape(typ.PrimitiveMap, refs)
+	case *irt.Field_ListMap:
+		return sliceOf(idx.refShape(typ.ListMap.Items, refs))
+	default:
+		panic(fmt.Sprintf("unreachable: unknown field type %T", typ))
+	}
+}
+
+// copyValue writes statements that deep-copy the value in into out, both
+// of which must be addressable expressions of the given shape.
+func copyValue(shape *goShape, in, out string, dst *bytes.Buffer) {
+	switch shape.kind {
+	case copyShallow:
+		fmt.Fprintf(dst, "%s = %s\n", out, in)
+	case copyDeep:
+		if shape.nilable {
+			fmt.Fprintf(dst, "if %s != nil {\n", in)
+			fmt.Fprintf(dst, "%s.DeepCopyInto(&%s)\n", in, out)
+			fmt.Fprintln(dst, "}")
+			break
+		}
+		fmt.Fprintf(dst, "%s.DeepCopyInto(&%s)\n", in, out)
+	case copyPtr:
+		fmt.Fprintf(dst, "if %s != nil {\n", in)
+		fmt.Fprintf(dst, "in, out := &%s, &%s\n", in, out)
+		fmt.Fprintf(dst, "*out = new(%s)\n", shape.elem.typeStr)
+		if shape.elem.kind == copyDeep {
+			fmt.Fprintln(dst, "(*in).DeepCopyInto(*out)")
+		} else {
+			copyValue(shape.elem, "**in", "**out", dst)
+		}
+		fmt.Fprintln(dst, "}")
+	case copySlice:
+		fmt.Fprintf(dst, "if %s != nil {\n", in)
+		fmt.Fprintf(dst, "in, out := &%s, &%s\n", in, out)
+		copyContents(shape, dst)
+		fmt.Fprintln(dst, "}")
+	case copyMap:
+		fmt.Fprintf(dst, "if %s != nil {\n", in)
+		fmt.Fprintf(dst, "in, out := &%s, &%s\n", in, out)
+		copyContents(shape, dst)
+		fmt.Fprintln(dst, "}")
+	default:
+		panic(fmt.Sprintf("unreachable: unknown copy kind %v", shape.kind))
+	}
+}
+
+// copyContents writes statements that copy the contents of the slice or map
+// pointed to by in into a new one pointed to by out.
+func copyContents(shape *goShape, dst *bytes.Buffer) {
+	fmt.Fprintf(dst, "*out = make(%s, len(*in))\n", shape.typeStr)
+	switch {
+	case shape.kind == copySlice && shape.elem.kind == copyShallow:
+		fmt.Fprintln(dst, "copy(*out, *in)")
+	case shape.kind == copySlice:
+		fmt.Fprintln(dst, "for i := range *in {")
+		copyValue(shape.elem, "(*in)[i]", "(*out)[i]", dst)
+		fmt.Fprintln(dst, "}")
+	case shape.elem.kind == copyShallow:
+		fmt.Fprintln(dst, "for key, val := range *in {")
+		fmt.Fprintln(dst, "(*out)[key] = val")
+		fmt.Fprintln(dst, "}")
+	default:
+		fmt.Fprintln(dst, "for key, val := range *in {")
+		fmt.Fprintf(dst, "var outVal %s\n", shape.elem.typeStr)
+		copyValue(shape.elem, "val", "outVal", dst)
+		fmt.Fprintln(dst, "(*out)[key] = outVal")
+		fmt.Fprintln(dst, "}")
+	}
+}
+
+// goFieldName figures out how to refer to a field in Go code, dealing with
+// the fact that embedded fields are named after their type.
+func goFieldName(field *irt.Field, shape *goShape) string {
+	if !field.Embedded {
+		return nameField(field.Name, field.Attributes)
+	}
+	name := shape.typeStr
+	if shape.kind == copyPtr {
+		name = shape.elem.typeStr
+	}
+	for i := len(name) - 1; i >= 0; i-- {
+		if name[i] == '.' {
+			return name[i+1:]
+		}
+	}
+	return name
+}
+
+type deepCopyWriter struct {
+	*pkgWriter
+	index *typeIndex
+}
+
+// structCopy writes DeepCopyInto & DeepCopy for a struct-like type.  Fields
+// that can be shallow-copied are already handled by the initial `*out = *in`.
+func (w *deepCopyWriter) structCopy(typeName string, fields []*irt.Field, extra func(*bytes.Buffer)) {
+	out := new(bytes.Buffer)
+	fmt.Fprintf(out, "// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.\n")
+	fmt.Fprintf(out, "func (in *%s) DeepCopyInto(out *%s) {\n", typeName, typeName)
+	fmt.Fprintln(out, "*out = *in")
+	if extra != nil {
+		extra(out)
+	}
+	for _, field := range fields {
+		shape := w.index.fieldShape(field, w)
+		if shape.kind == copyShallow {
+			continue
+		}
+		name := goFieldName(field, shape)
+		copyValue(shape, "in."+name, "out."+name, out)
+	}
+	fmt.Fprintln(out, "}")
+	fmt.Fprintln(out, "")
+
+	fmt.Fprintf(out, "// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new %s.\n", typeName)
+	fmt.Fprintf(out, "func (in *%s) DeepCopy() *%s {\n", typeName, typeName)
+	fmt.Fprintln(out, "if in == nil {\nreturn nil\n}")
+	fmt.Fprintf(out, "out := new(%s)\n", typeName)
+	fmt.Fprintln(out, "in.DeepCopyInto(out)\nreturn out\n}")
+	w.Code(typeName, out)
+}
+
+// objectCopy writes DeepCopyObject, so that kinds (and lists thereof)
+// implement runtime.Object.
+func (w *deepCopyWriter) objectCopy(typeName string) {
+	runtime := w.Import("k8s.io/apimachinery/pkg/runtime", "runtime")
+	out := w.types[w.typeInds[typeName]].contents
+	fmt.Fprintln(out, "")
+	fmt.Fprintf(out, "// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new %s.Object.\n", runtime)
+	fmt.Fprintf(out, "func (in *%s) DeepCopyObject() %s.Object {\n", typeName, runtime)
+	fmt.Fprintln(out, "if c := in.DeepCopy(); c != nil {\nreturn c\n}\nreturn nil\n}")
+}
+
+// containerCopy writes DeepCopyInto & DeepCopy for a named slice or map.
+// These have value receivers, like the ones generated by deepcopy-gen.
+func (w *deepCopyWriter) containerCopy(typeName string, shape *goShape) {
+	named := *shape
+	named.typeStr = typeName
+
+	out := new(bytes.Buffer)
+	fmt.Fprintf(out, "// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.\n")
+	fmt.Fprintf(out, "func (in %s) DeepCopyInto(out *%s) {\n", typeName, typeName)
+	fmt.Fprintln(out, "{\nin := &in")
+	copyContents(&named, out)
+	fmt.Fprintln(out, "}\n}")
+	fmt.Fprintln(out, "")
+
+	fmt.Fprintf(out, "// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new %s.\n", typeName)
+	fmt.Fprintf(out, "func (in %s) DeepCopy() %s {\n", typeName, typeName)
+	fmt.Fprintln(out, "if in == nil {\nreturn nil\n}")
+	fmt.Fprintf(out, "out := new(%s)\n", typeName)
+	fmt.Fprintln(out, "in.DeepCopyInto(out)\nreturn *out\n}")
+	w.Code(typeName, out)
+}
+
+// aliasCopy writes DeepCopyInto & DeepCopy for a named type whose
+// underlying type has its own DeepCopyInto (which our named type doesn't
+// inherit), by converting to the underlying type.
+func (w *deepCopyWriter) aliasCopy(typeName string, underlying *goShape) {
+	out := new(bytes.Buffer)
+	fmt.Fprintf(out, "// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.\n")
+	fmt.Fprintf(out, "func (in *%s) DeepCopyInto(out *%s) {\n", typeName, typeName)
+	fmt.Fprintf(out, "(*%[1]s)(in).DeepCopyInto((*%[1]s)(out))\n", underlying.typeStr)
+	fmt.Fprintln(out, "}")
+	fmt.Fprintln(out, "")
+
+	fmt.Fprintf(out, "// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new %s.\n", typeName)
+	fmt.Fprintf(out, "func (in *%s) DeepCopy() *%s {\n", typeName, typeName)
+	fmt.Fprintln(out, "if in == nil {\nreturn nil\n}")
+	fmt.Fprintf(out, "out := new(%s)\n", typeName)
+	fmt.Fprintln(out, "in.DeepCopyInto(out)\nreturn out\n}")
+	w.Code(typeName, out)
+}
+
+// writeDeepCopy writes deepcopy functions for all the types in the given
+// group-version, matching what writeGo generates.
+func writeDeepCopy(inputs []*ir.GroupVersion, out *deepCopyWriter) {
+	// we compute Go types for fields that we don't end up copying
+	out.PruneImports = true
+	fmt.Fprint(out.header, "// Code generated by ckdl-to-tokgo. DO NOT EDIT.\n\n")
+	out.Package(comment{})
+
+	for _, gv := range inputs {
+		for _, kind := range gv.Kinds {
+			typeName := nameType(kind.Name, kind.Attributes)
+			out.structCopy(typeName, kind.Fields, func(dst *bytes.Buffer) {
+				if kind.Object {
+					fmt.Fprintln(dst, "in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)")
+				}
+			})
+			out.objectCopy(typeName)
+			if !kind.Object {
+				continue
+			}
+
+			listName := typeName + "List"
+			out.structCopy(listName, nil, func(dst *bytes.Buffer) {
+				fmt.Fprintln(dst, "in.ListMeta.DeepCopyInto(&out.ListMeta)")
+				copyValue(sliceOf(deep(typeName)), "in.Items", "out.Items", dst)
+			})
+			out.objectCopy(listName)
+		}
+		for _, subtype := range gv.Types {
+			typeName := nameType(subtype.Name, subtype.Attributes)
+			switch body := subtype.Type.(type) {
+			case *irt.Subtype_Struct:
+				out.structCopy(typeName, body.Struct.Fields, nil)
+			case *irt.Subtype_Union:
+				out.structCopy(typeName, unionVariants(body.Union), nil)
+			case *irt.Subtype_Set:
+				out.containerCopy(typeName, out.index.setShape(body.Set, out))
+			case *irt.Subtype_List:
+				out.containerCopy(typeName, out.index.listShape(body.List, out))
+			case *irt.Subtype_PrimitiveMap:
+				out.containerCopy(typeName, out.index.primMapShape(body.PrimitiveMap, out))
+			case *irt.Subtype_ListMap:
+				out.containerCopy(typeName, sliceOf(out.index.refShape(body.ListMap.Items, out)))
+			case *irt.Subtype_PrimitiveAlias:
+				switch shape := primShape(body.PrimitiveAlias, out); shape.kind {
+				case copyDeep:
+					out.aliasCopy(typeName, shape)
+				case copySlice:
+					out.containerCopy(typeName, shape)
+				}
+			case *irt.Subtype_ReferenceAlias:
+				if shape := out.index.refShape(body.ReferenceAlias, out); shape.kind == copyDeep {
+					out.aliasCopy(typeName, shape)
+				}
+			case *irt.Subtype_Enum:
+				// just a string
+			default:
+				panic(fmt.Sprintf("unreachable: unknown subtype type %T", body))
+			}
+		}
+	}
+}
@@ -0,0 +1,743 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package tokgo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	ir "k8s.io/idl/ckdl-ir/goir"
+	irt "k8s.io/idl/ckdl-ir/goir/types"
+)
+
+// NB(directxman12): defaults get checked against their field's type when we
+// generate code, and written out as typed Go literals, so a bad default is a
+// generation error instead of a panic at runtime.  Like the API server, we
+// default a field first, then default the fields of the (possibly new) value.
+
+// defaultedTypes figures out which types (transitively) contain defaults,
+// and thus need a SetDefaults function.
+func defaultedTypes(idx *typeIndex) map[typeKey]bool {
+	needed := make(map[typeKey]bool)
+
+	// types may be recursive, so just keep going till nothing changes
+	fieldsNeed := func(fields []*irt.Field) bool {
+		for _, field := range fields {
+			if field.Default != nil {
+				return true
+			}
+			if ref := fieldRef(field); ref != nil && needed[keyFor(ref)] {
+				return true
+			}
+		}
+		return false
+	}
+	for changed := true; changed; {
+		changed = false
+		for key, kind := range idx.Kinds {
+			if !needed[key] && fieldsNeed(kind.Fields) {
+				needed[key] = true
+				changed = true
+			}
+		}
+		for key, subtype := range idx.Subtypes {
+			if needed[key] {
+				continue
+			}
+			var needs bool
+			switch body := subtype.Type.(type) {
+			case *irt.Subtype_Struct:
+				needs = fieldsNeed(body.Struct.Fields)
+			case *irt.Subtype_Union:
+				needs = fieldsNeed(unionVariants(body.Union))
+			default:
+				ref := wrapperRef(subtype)
+				needs = ref != nil && needed[keyFor(ref)]
+			}
+			if needs {
+				needed[key] = true
+				changed = true
+			}
+		}
+	}
+	return needed
+}
+
+// fieldRef returns the type referenced by the values of the given field, if any.
+func fieldRef(field *irt.Field) *irt.Reference {
+	switch typ := field.Type.(type) {
+	case *irt.Field_NamedType:
+		return typ.NamedType
+	case *irt.Field_Set:
+		return typ.Set.GetReference()
+	case *irt.Field_List:
+		return typ.List.GetReference()
+	case *irt.Field_PrimitiveMap:
+		return typ.PrimitiveMap.GetReferenceValue()
+	case *irt.Field_ListMap:
+		return typ.ListMap.Items
+	default:
+		return nil
+	}
+}
+
+// wrapperRef returns the type wrapped by (or contained in) the given
+// subtype, if any.
+func wrapperRef(subtype *irt.Subtype) *irt.Reference {
+	switch body := subtype.Type.(type) {
+	case *irt.Subtype_ReferenceAlias:
+		return body.ReferenceAlias
+	case *irt.Subtype_Set:
+		return body.Set.GetReference()
+	case *irt.Subtype_List:
+		return body.List.GetReference()
+	case *irt.Subtype_PrimitiveMap:
+		return body.PrimitiveMap.GetReferenceValue()
+	case *irt.Subtype_ListMap:
+		return body.ListMap.Items
+	default:
+		return nil
+	}
+}
+
+type defaultsWriter struct {
+	*pkgWriter
+	index  *typeIndex
+	needed map[typeKey]bool
+
+	// ptrVars counts the temporary variables used for pointer defaults
+	ptrVars int
+}
+
+// callDefaults writes a call to the SetDefaults function for the referenced
+// type, if it has one.  addr must be a pointer to a value of that type.
+func (w *defaultsWriter) callDefaults(ref *irt.Reference, addr string, dst *bytes.Buffer) {
+	if ref == nil || !w.needed[keyFor(ref)] {
+		return
+	}
+	fmt.Fprintf(dst, "%s(%s)\n", funcForRef(w, ref, "SetDefaults_"), addr)
+}
+
+// eachDefaults writes a loop that defaults each item of the given slice or
+// map.  Map values aren't addressable, so those get defaulted as a copy.
+func (w *defaultsWriter) eachDefaults(ref *irt.Reference, expr string, isMap bool, dst *bytes.Buffer) {
+	if ref == nil || !w.needed[keyFor(ref)] {
+		return
+	}
+	if isMap {
+		fmt.Fprintf(dst, "for key, val := range %s {\n", expr)
+		w.callDefaults(ref, "&val", dst)
+		fmt.Fprintf(dst, "%s[key] = val\n", expr)
+		fmt.Fprintln(dst, "}")
+		return
+	}
+	fmt.Fprintf(dst, "for i := range %s {\n", expr)
+	w.callDefaults(ref, fmt.Sprintf("&%s[i]", expr), dst)
+	fmt.Fprintln(dst, "}")
+}
+
+// scalarLiteral writes the given default as an (untyped) Go constant of the
+// given primitive type.
+func scalarLiteral(prim irt.Primitive_Type, val *structpb.Value) (string, error) {
+	switch prim {
+	case irt.Primitive_STRING:
+		if str, isStr := val.Kind.(*structpb.Value_StringValue); isStr {
+			return strconv.Quote(str.StringValue), nil
+		}
+	case irt.Primitive_LEGACYINT32, irt.Primitive_INT64:
+		if num, isNum := val.Kind.(*structpb.Value_NumberValue); isNum && num.NumberValue == math.Trunc(num.NumberValue) {
+			return strconv.FormatInt(int64(num.NumberValue), 10), nil
+		}
+	case irt.Primitive_LEGACYFLOAT64:
+		if num, isNum := val.Kind.(*structpb.Value_NumberValue); isNum {
+			return strconv.FormatFloat(num.NumberValue, 'g', -1, 64), nil
+		}
+	case irt.Primitive_BOOL:
+		if b, isBool := val.Kind.(*structpb.Value_BoolValue); isBool {
+			return strconv.FormatBool(b.BoolValue), nil
+		}
+	}
+	return "", fmt.Errorf("default %v is not a valid %v", val.AsInterface(), prim)
+}
+
+// scalarZero returns the zero value of the given primitive type, for
+// comparison.
+func scalarZero(prim irt.Primitive_Type) string {
+	switch prim {
+	case irt.Primitive_STRING:
+		return `""`
+	case irt.Primitive_BOOL:
+		return "false"
+	default:
+		return "0"
+	}
+}
+
+// goString quotes the given string as a raw string literal if possible,
+// since it's much more readable for things like regular expressions.
+func goString(str string) string {
+	if strings.ContainsAny(str, "`\r") {
+		return strconv.Quote(str)
+	}
+	return "`" + str + "`"
+}
+
+// defaultField writes statements that set the given field to its default
+// value if it's unset.
+func (w *defaultsWriter) defaultField(field *irt.Field, expr string, shape *goShape, dst *bytes.Buffer) {
+	vars := new(bytes.Buffer)
+	lit, err := w.fieldLiteral(field, shape, field.Default, vars)
+	if err != nil {
+		w.Resp.ErrorAt(w.Resp.Node(field.Default), err, "invalid default for field", "field", field.Name)
+		return
+	}
+	fmt.Fprintf(dst, "if %s {\n", w.zeroCheck(field, shape, expr))
+	dst.Write(vars.Bytes())
+	fmt.Fprintf(dst, "%s = %s\n", expr, lit)
+	fmt.Fprintln(dst, "}")
+}
+
+// zeroCheck returns a Go condition that's true when the given field's value
+// hasn't been set.  Non-pointer structs have no way to say that, so those
+// count as unset when they're entirely empty.
+func (w *defaultsWriter) zeroCheck(field *irt.Field, shape *goShape, expr string) string {
+	switch {
+	case shape.kind == copyPtr, shape.kind == copySlice, shape.kind == copyMap, shape.nilable:
+		return expr + " == nil"
+	}
+	if prim, isScalar := w.index.scalarType(field); isScalar {
+		return expr + " == " + scalarZero(prim)
+	}
+	ref := fieldRef(field)
+	if ref == nil || w.comparableRef(ref, make(map[typeKey]bool)) {
+		return fmt.Sprintf("%s == (%s{})", expr, shape.typeStr)
+	}
+	// something in the struct can't be compared with ==, so check each
+	// field of it instead
+	fields := w.structFields(ref)
+	if len(fields) == 0 {
+		return "true"
+	}
+	checks := make([]string, len(fields))
+	for i, inner := range fields {
+		innerShape := w.index.fieldShape(inner, w)
+		checks[i] = w.zeroCheck(inner, innerShape, expr+"."+goFieldName(inner, innerShape))
+	}
+	return "(" + strings.Join(checks, " && ") + ")"
+}
+
+// structFields returns the Go fields of the struct or union that the given
+// reference (eventually) points to, if it's one.
+func (w *defaultsWriter) structFields(ref *irt.Reference) []*irt.Field {
+	subtype, known := w.index.Subtypes[keyFor(ref)]
+	if !known {
+		return nil
+	}
+	switch body := subtype.Type.(type) {
+	case *irt.Subtype_Struct:
+		return body.Struct.Fields
+	case *irt.Subtype_Union:
+		return unionVariants(body.Union)
+	case *irt.Subtype_ReferenceAlias:
+		return w.structFields(body.ReferenceAlias)
+	default:
+		return nil
+	}
+}
+
+// comparableRef checks if values of the given type can be compared with ==
+// in Go (i.e. there are no slices or maps in them, short of pointers).
+func (w *defaultsWriter) comparableRef(ref *irt.Reference, seen map[typeKey]bool) bool {
+	key := keyFor(ref)
+	if seen[key] {
+		return true
+	}
+	seen[key] = true
+	if _, isKind := w.index.Kinds[key]; isKind {
+		return false
+	}
+	subtype, known := w.index.Subtypes[key]
+	if !known {
+		return specialPrim(ref) != nil
+	}
+	var fields []*irt.Field
+	switch body := subtype.Type.(type) {
+	case *irt.Subtype_Enum:
+		return true
+	case *irt.Subtype_PrimitiveAlias:
+		return body.PrimitiveAlias.Type != irt.Primitive_BYTES
+	case *irt.Subtype_ReferenceAlias:
+		return w.comparableRef(body.ReferenceAlias, seen)
+	case *irt.Subtype_Struct:
+		fields = body.Struct.Fields
+	case *irt.Subtype_Union:
+		fields = unionVariants(body.Union)
+	default:
+		return false
+	}
+	for _, field := range fields {
+		shape := w.index.fieldShape(field, w)
+		switch {
+		case shape.kind == copyPtr:
+			continue
+		case shape.kind == copySlice, shape.kind == copyMap, shape.nilable:
+			return false
+		}
+		if named, isNamed := field.Type.(*irt.Field_NamedType); isNamed && !w.comparableRef(named.NamedType, seen) {
+			return false
+		}
+	}
+	return true
+}
+
+// specialPrim returns the primitive that the given reference was
+// synthesized for, if any (see primType).
+func specialPrim(ref *irt.Reference) *irt.Primitive {
+	switch {
+	case sameRef(ref, timeRef):
+		return &irt.Primitive{Type: irt.Primitive_TIME}
+	case sameRef(ref, durationRef):
+		return &irt.Primitive{Type: irt.Primitive_DURATION}
+	case sameRef(ref, quantityRef):
+		return &irt.Primitive{Type: irt.Primitive_QUANTITY}
+	case sameRef(ref, intStrRef):
+		return &irt.Primitive{Type: irt.Primitive_INTORSTRING}
+	default:
+		return nil
+	}
+}
+
+// fieldLiteral checks the given default against the field's type, and
+// returns it as a Go expression of the field's Go type.  Pointers need
+// something to point to, so those values get declared as variables in vars,
+// which must come before the expression.
+func (w *defaultsWriter) fieldLiteral(field *irt.Field, shape *goShape, val *structpb.Value, vars *bytes.Buffer) (string, error) {
+	if shape.kind == copyPtr {
+		lit, err := w.fieldLiteral(field, shape.elem, val, vars)
+		if err != nil {
+			return "", err
+		}
+		w.ptrVars++
+		fmt.Fprintf(vars, "var ptrVar%d %s = %s\n", w.ptrVars, shape.elem.typeStr, lit)
+		return fmt.Sprintf("&ptrVar%d", w.ptrVars), nil
+	}
+	switch typ := field.Type.(type) {
+	case *irt.Field_Primitive:
+		return w.primLiteral(typ.Primitive, shape, val)
+	case *irt.Field_NamedType:
+		return w.refLiteral(typ.NamedType, shape, val, vars)
+	case *irt.Field_Set:
+		return w.listLiteral(typ.Set.GetPrimitive(), typ.Set.GetReference(), shape, val, vars)
+	case *irt.Field_List:
+		return w.listLiteral(typ.List.GetPrimitive(), typ.List.GetReference(), shape, val, vars)
+	case *irt.Field_ListMap:
+		return w.listLiteral(nil, typ.ListMap.Items, shape, val, vars)
+	case *irt.Field_PrimitiveMap:
+		return w.mapLiteral(typ.PrimitiveMap, shape, val, vars)
+	default:
+		panic(fmt.Sprintf("unreachable: unknown field type %T", typ))
+	}
+}
+
+// itemLiteral returns a literal for a value that's either a primitive or a
+// reference, like list items & map keys or values.
+func (w *defaultsWriter) itemLiteral(prim *irt.Primitive, ref *irt.Reference, shape *goShape, val *structpb.Value, vars *bytes.Buffer) (string, error) {
+	if prim != nil {
+		return w.primLiteral(prim, shape, val)
+	}
+	return w.refLiteral(ref, shape, val, vars)
+}
+
+func (w *defaultsWriter) listLiteral(prim *irt.Primitive, ref *irt.Reference, shape *goShape, val *structpb.Value, vars *bytes.Buffer) (string, error) {
+	list, isList := val.Kind.(*structpb.Value_ListValue)
+	if !isList {
+		return "", fmt.Errorf("default %v is not a list", val.AsInterface())
+	}
+	items := make([]string, len(list.ListValue.Values))
+	for i, item := range list.ListValue.Values {
+		lit, err := w.itemLiteral(prim, ref, shape.elem, item, vars)
+		if err != nil {
+			return "", fmt.Errorf("item %d: %w", i, err)
+		}
+		items[i] = lit
+	}
+	return fmt.Sprintf("%s{%s}", shape.typeStr, strings.Join(items, ", ")), nil
+}
+
+func (w *defaultsWriter) mapLiteral(primMap *irt.PrimitiveMap, shape *goShape, val *structpb.Value, vars *bytes.Buffer) (string, error) {
+	obj, isObj := val.Kind.(*structpb.Value_StructValue)
+	if !isObj {
+		return "", fmt.Errorf("default %v is not a map", val.AsInterface())
+	}
+	// the key's shape isn't kept around, but keys are always scalars, so
+	// they don't need it
+	keyShape := shallow("")
+	keys := make([]string, 0, len(obj.StructValue.Fields))
+	for key := range obj.StructValue.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	entries := make([]string, len(keys))
+	for i, key := range keys {
+		keyLit, err := w.itemLiteral(primMap.GetPrimitiveKey(), primMap.GetReferenceKey(), keyShape, structpb.NewStringValue(key), vars)
+		if err != nil {
+			return "", fmt.Errorf("key %q: %w", key, err)
+		}
+		item := obj.StructValue.Fields[key]
+		var valLit string
+		if list := primMap.GetSimpleListValue(); list != nil {
+			valLit, err = w.listLiteral(list.GetPrimitive(), list.GetReference(), shape.elem, item, vars)
+		} else {
+			valLit, err = w.itemLiteral(primMap.GetPrimitiveValue(), primMap.GetReferenceValue(), shape.elem, item, vars)
+		}
+		if err != nil {
+			return "", fmt.Errorf("key %q: %w", key, err)
+		}
+		entries[i] = keyLit + ": " + valLit
+	}
+	return fmt.Sprintf("%s{%s}", shape.typeStr, strings.Join(entries, ", ")), nil
+}
+
+// primLiteral returns a literal for a primitive, checking the special ones
+// the same way the API machinery would parse them.
+func (w *defaultsWriter) primLiteral(prim *irt.Primitive, shape *goShape, val *structpb.Value) (string, error) {
+	if scalar, isScalar := scalarPrim(prim); isScalar {
+		return scalarLiteral(scalar, val)
+	}
+	str, isStr := val.Kind.(*structpb.Value_StringValue)
+	switch prim.Type {
+	case irt.Primitive_BYTES:
+		if isStr {
+			if raw, err := base64.StdEncoding.DecodeString(str.StringValue); err == nil {
+				return fmt.Sprintf("[]byte(%s)", strconv.Quote(string(raw))), nil
+			}
+		}
+	case irt.Primitive_TIME:
+		if isStr {
+			if parsed, err := time.Parse(time.RFC3339, str.StringValue); err == nil {
+				parsed = parsed.UTC()
+				timePkg := w.Import("time", "time")
+				return fmt.Sprintf("%s{Time: %s.Date(%d, %d, %d, %d, %d, %d, %d, %s.UTC)}", shape.typeStr, timePkg,
+					parsed.Year(), parsed.Month(), parsed.Day(), parsed.Hour(), parsed.Minute(), parsed.Second(), parsed.Nanosecond(), timePkg), nil
+			}
+		}
+	case irt.Primitive_DURATION:
+		if isStr {
+			if parsed, err := time.ParseDuration(str.StringValue); err == nil {
+				return fmt.Sprintf("%s{Duration: %d}", shape.typeStr, int64(parsed)), nil
+			}
+		}
+	case irt.Primitive_QUANTITY:
+		var raw string
+		switch kind := val.Kind.(type) {
+		case *structpb.Value_StringValue:
+			raw = kind.StringValue
+		case *structpb.Value_NumberValue:
+			raw = strconv.FormatFloat(kind.NumberValue, 'f', -1, 64)
+		}
+		if _, err := resource.ParseQuantity(raw); err == nil {
+			return fmt.Sprintf("%s(%s)", pkgPrefix(shape.typeStr)+"MustParse", strconv.Quote(raw)), nil
+		}
+	case irt.Primitive_INTORSTRING:
+		switch kind := val.Kind.(type) {
+		case *structpb.Value_StringValue:
+			return fmt.Sprintf("%s{Type: %sString, StrVal: %s}", shape.typeStr, pkgPrefix(shape.typeStr), strconv.Quote(kind.StringValue)), nil
+		case *structpb.Value_NumberValue:
+			if kind.NumberValue == math.Trunc(kind.NumberValue) && kind.NumberValue >= math.MinInt32 && kind.NumberValue <= math.MaxInt32 {
+				return fmt.Sprintf("%s{Type: %sInt, IntVal: %d}", shape.typeStr, pkgPrefix(shape.typeStr), int32(kind.NumberValue)), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("default %v is not a valid %v", val.AsInterface(), prim.Type)
+}
+
+// pkgPrefix returns the package qualifier (with the dot) of the given Go
+// type, if it has one.
+func pkgPrefix(typeStr string) string {
+	if ind := strings.LastIndex(typeStr, "."); ind >= 0 {
+		return typeStr[:ind+1]
+	}
+	return ""
+}
+
+// refLiteral returns a literal for a value of a named type.
+func (w *defaultsWriter) refLiteral(ref *irt.Reference, shape *goShape, val *structpb.Value, vars *bytes.Buffer) (string, error) {
+	typeStr := w.MakeGVRef(ref)
+	key := keyFor(ref)
+	if _, isKind := w.index.Kinds[key]; isKind {
+		return "", fmt.Errorf("defaults for kinds (%s) aren't supported", ref.Name)
+	}
+	subtype, known := w.index.Subtypes[key]
+	if !known {
+		if prim := specialPrim(ref); prim != nil {
+			return w.primLiteral(prim, shallow(typeStr), val)
+		}
+		return "", fmt.Errorf("unable to check a default for unknown type %s", ref.Name)
+	}
+
+	switch body := subtype.Type.(type) {
+	case *irt.Subtype_Enum:
+		if str, isStr := val.Kind.(*structpb.Value_StringValue); isStr {
+			for _, variant := range body.Enum.Variants {
+				if variant.Name == str.StringValue {
+					return fmt.Sprintf("%s(%s)", typeStr, strconv.Quote(str.StringValue)), nil
+				}
+			}
+		}
+		return "", fmt.Errorf("default %v is not one of the values of %s", val.AsInterface(), ref.Name)
+	case *irt.Subtype_PrimitiveAlias:
+		lit, err := w.primLiteral(body.PrimitiveAlias, primShape(body.PrimitiveAlias, w), val)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s(%s)", typeStr, lit), nil
+	case *irt.Subtype_ReferenceAlias:
+		lit, err := w.refLiteral(body.ReferenceAlias, w.index.refShape(body.ReferenceAlias, w), val, vars)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s(%s)", typeStr, lit), nil
+	case *irt.Subtype_Struct:
+		return w.structLiteral(typeStr, body.Struct.Fields, nil, val, vars)
+	case *irt.Subtype_Union:
+		return w.structLiteral(typeStr, unionVariants(body.Union), body.Union, val, vars)
+	}
+
+	// named containers are written the same as unnamed ones, just with
+	// their own name
+	named := *w.index.refShape(ref, w)
+	switch body := subtype.Type.(type) {
+	case *irt.Subtype_Set:
+		named.elem = w.index.setShape(body.Set, w).elem
+		return w.listLiteral(body.Set.GetPrimitive(), body.Set.GetReference(), &named, val, vars)
+	case *irt.Subtype_List:
+		named.elem = w.index.listShape(body.List, w).elem
+		return w.listLiteral(body.List.GetPrimitive(), body.List.GetReference(), &named, val, vars)
+	case *irt.Subtype_ListMap:
+		named.elem = w.index.refShape(body.ListMap.Items, w)
+		return w.listLiteral(nil, body.ListMap.Items, &named, val, vars)
+	case *irt.Subtype_PrimitiveMap:
+		named.elem = w.index.primMapShape(body.PrimitiveMap, w).elem
+		return w.mapLiteral(body.PrimitiveMap, &named, val, vars)
+	default:
+		panic(fmt.Sprintf("unreachable: unknown subtype %T", body))
+	}
+}
+
+// structLiteral returns a literal for a struct (or union, if given), making
+// sure every key in the default is a field of it.
+func (w *defaultsWriter) structLiteral(typeStr string, fields []*irt.Field, union *irt.Union, val *structpb.Value, vars *bytes.Buffer) (string, error) {
+	obj, isObj := val.Kind.(*structpb.Value_StructValue)
+	if !isObj {
+		return "", fmt.Errorf("default %v is not an object", val.AsInterface())
+	}
+	used := make(map[string]bool)
+	var entries []string
+	if union != nil {
+		var set []string
+		for _, variant := range union.Variants {
+			if _, present := obj.StructValue.Fields[variant.Name]; present {
+				set = append(set, variant.Name)
+			}
+		}
+		if len(set) > 1 {
+			return "", fmt.Errorf("default sets more than one variant of the union (%s)", strings.Join(set, ", "))
+		}
+		if !union.Untagged {
+			if tagVal, present := obj.StructValue.Fields[union.Tag]; present {
+				tagStr, isStr := tagVal.Kind.(*structpb.Value_StringValue)
+				if !isStr || !hasVariant(union, tagStr.StringValue) {
+					return "", fmt.Errorf("%s %v is not one of the variants of the union", union.Tag, tagVal.AsInterface())
+				}
+				if len(set) == 1 && set[0] != tagStr.StringValue {
+					return "", fmt.Errorf("%s is %q, but the default sets %s", union.Tag, tagStr.StringValue, set[0])
+				}
+				used[union.Tag] = true
+				entries = append(entries, fmt.Sprintf("%s: %s", strings.Title(union.Tag), strconv.Quote(tagStr.StringValue)))
+			}
+		}
+	}
+	fieldEntries, err := w.fieldEntries(fields, obj.StructValue, used, vars)
+	if err != nil {
+		return "", err
+	}
+	entries = append(entries, fieldEntries...)
+
+	for key := range obj.StructValue.Fields {
+		if !used[key] {
+			return "", fmt.Errorf("%q is not a field of %s", key, typeStr)
+		}
+	}
+	return fmt.Sprintf("%s{%s}", typeStr, strings.Join(entries, ", ")), nil
+}
+
+// fieldEntries returns the "Field: value" parts of a struct literal for the
+// keys of obj that are fields in the given list, noting the ones it used.
+// Embedded fields are flattened in JSON, so their fields come from obj too.
+func (w *defaultsWriter) fieldEntries(fields []*irt.Field, obj *structpb.Struct, used map[string]bool, vars *bytes.Buffer) ([]string, error) {
+	var entries []string
+	for _, field := range fields {
+		shape := w.index.fieldShape(field, w)
+		if field.Embedded {
+			ref := fieldRef(field)
+			inner := w.structFields(ref)
+			if ref == nil || inner == nil {
+				continue
+			}
+			innerEntries, err := w.fieldEntries(inner, obj, used, vars)
+			if err != nil {
+				return nil, err
+			}
+			if len(innerEntries) > 0 {
+				entries = append(entries, fmt.Sprintf("%s: %s{%s}", goFieldName(field, shape), shape.typeStr, strings.Join(innerEntries, ", ")))
+			}
+			continue
+		}
+		item, present := obj.Fields[field.Name]
+		if !present {
+			continue
+		}
+		used[field.Name] = true
+		lit, err := w.fieldLiteral(field, shape, item, vars)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		entries = append(entries, fmt.Sprintf("%s: %s", goFieldName(field, shape), lit))
+	}
+	return entries, nil
+}
+
+func hasVariant(union *irt.Union, name string) bool {
+	for _, variant := range union.Variants {
+		if variant.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// recurseField writes statements that default the contents of the given
+// field's value(s), if its type has defaults of its own.
+func (w *defaultsWriter) recurseField(field *irt.Field, expr string, shape *goShape, dst *bytes.Buffer) {
+	ref := fieldRef(field)
+	if ref == nil || !w.needed[keyFor(ref)] {
+		return
+	}
+	switch field.Type.(type) {
+	case *irt.Field_NamedType:
+		if shape.kind == copyPtr {
+			fmt.Fprintf(dst, "if %s != nil {\n", expr)
+			w.callDefaults(ref, expr, dst)
+			fmt.Fprintln(dst, "}")
+			return
+		}
+		w.callDefaults(ref, "&"+expr, dst)
+	case *irt.Field_PrimitiveMap:
+		w.eachDefaults(ref, expr, true, dst)
+	default:
+		w.eachDefaults(ref, expr, false, dst)
+	}
+}
+
+// structDefaults writes SetDefaults for a struct-like type.
+func (w *defaultsWriter) structDefaults(typeName string, fields []*irt.Field) {
+	out := new(bytes.Buffer)
+	fmt.Fprintf(out, "// SetDefaults_%[1]s sets any unset fields in the given %[1]s to their default values.\n", typeName)
+	fmt.Fprintf(out, "func SetDefaults_%[1]s(in *%[1]s) {\n", typeName)
+	for _, field := range fields {
+		shape := w.index.fieldShape(field, w)
+		expr := "in." + goFieldName(field, shape)
+		if field.Default != nil {
+			w.defaultField(field, expr, shape, out)
+		}
+		w.recurseField(field, expr, shape, out)
+	}
+	fmt.Fprintln(out, "}")
+	w.Code(typeName, out)
+}
+
+// wrapperDefaults writes SetDefaults for a named container or alias,
+// which just defaults the values it contains.
+func (w *defaultsWriter) wrapperDefaults(typeName string, subtype *irt.Subtype) {
+	ref := wrapperRef(subtype)
+	out := new(bytes.Buffer)
+	fmt.Fprintf(out, "// SetDefaults_%[1]s sets any unset fields in the values of the given %[1]s to their default values.\n", typeName)
+	fmt.Fprintf(out, "func SetDefaults_%[1]s(in *%[1]s) {\n", typeName)
+	switch subtype.Type.(type) {
+	case *irt.Subtype_ReferenceAlias:
+		w.callDefaults(ref, fmt.Sprintf("(*%s)(in)", w.MakeGVRef(ref)), out)
+	case *irt.Subtype_PrimitiveMap:
+		w.eachDefaults(ref, "(*in)", true, out)
+	default:
+		w.eachDefaults(ref, "(*in)", false, out)
+	}
+	fmt.Fprintln(out, "}")
+	w.Code(typeName, out)
+}
+
+// writeDefaults writes defaulting functions for all the types in the given
+// group-version that have defaults, plus RegisterDefaults to hook the ones
+// for kinds up to a scheme, like defaulter-gen does.
+func writeDefaults(inputs []*ir.GroupVersion, out *defaultsWriter) {
+	out.PruneImports = true
+	fmt.Fprint(out.header, "// Code generated by ckdl-to-tokgo. DO NOT EDIT.\n\n")
+	out.Package(comment{})
+
+	runtime := out.Import("k8s.io/apimachinery/pkg/runtime", "runtime")
+	register := new(bytes.Buffer)
+	fmt.Fprintln(register, "// RegisterDefaults adds defaulters functions to the given scheme.")
+	fmt.Fprintln(register, "// Public to allow building arbitrary schemes.")
+	fmt.Fprintln(register, "// All generated defaulters are covering - they call all nested defaulters.")
+	fmt.Fprintf(register, "func RegisterDefaults(scheme *%s.Scheme) error {\n", runtime)
+	out.Code("RegisterDefaults", register)
+
+	for _, gv := range inputs {
+		for _, kind := range gv.Kinds {
+			if !out.needed[typeKey{GroupVersion: out.CurrentGV, FullName: kind.Name}] {
+				continue
+			}
+			typeName := nameType(kind.Name, kind.Attributes)
+			out.structDefaults(typeName, kind.Fields)
+			fmt.Fprintf(register, "scheme.AddTypeDefaultingFunc(&%[1]s{}, func(obj interface{}) { SetDefaults_%[1]s(obj.(*%[1]s)) })\n", typeName)
+			if !kind.Object {
+				continue
+			}
+
+			listName := typeName + "List"
+			list := new(bytes.Buffer)
+			fmt.Fprintf(list, "// SetDefaults_%[1]s sets any unset fields in the items of the given %[1]s to their default values.\n", listName)
+			fmt.Fprintf(list, "func SetDefaults_%[1]s(in *%[1]s) {\n", listName)
+			fmt.Fprintln(list, "for i := range in.Items {")
+			fmt.Fprintf(list, "SetDefaults_%s(&in.Items[i])\n", typeName)
+			fmt.Fprintln(list, "}\n}")
+			out.Code(listName, list)
+
+			fmt.Fprintf(register, "scheme.AddTypeDefaultingFunc(&%[1]s{}, func(obj interface{}) { SetDefaults_%[1]s(obj.(*%[1]s)) })\n", listName)
+		}
+		for _, subtype := range gv.Types {
+			if !out.needed[typeKey{GroupVersion: out.CurrentGV, FullName: subtype.Name}] {
+				continue
+			}
+			typeName := nameType(subtype.Name, subtype.Attributes)
+			switch body := subtype.Type.(type) {
+			case *irt.Subtype_Struct:
+				out.structDefaults(typeName, body.Struct.Fields)
+			case *irt.Subtype_Union:
+				out.structDefaults(typeName, unionVariants(body.Union))
+			default:
+				out.wrapperDefaults(typeName, subtype)
+			}
+		}
+	}
+
+	fmt.Fprintln(register, "return nil\n}")
+}
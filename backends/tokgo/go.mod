@@ -1,15 +1,42 @@
 module k8s.io/idl/backends/tokgo
 
-go 1.15
+go 1.22.0
 
 replace (
 	k8s.io/idl/backends/common => ../common
+	k8s.io/idl/backends/tocrd => ../tocrd
 	k8s.io/idl/ckdl-ir/goir => ../../ckdl-ir/goir
 )
 
 require (
-	github.com/golang/protobuf v1.4.3
-	google.golang.org/protobuf v1.25.0
+	github.com/gobuffalo/flect v0.2.2
+	github.com/golang/protobuf v1.5.3
+	google.golang.org/protobuf v1.30.0
+	k8s.io/apiextensions-apiserver v0.28.3
+	k8s.io/apimachinery v0.28.3
+	k8s.io/apimachinery v0.28.3
 	k8s.io/idl/backends/common v0.0.0-00010101000000-000000000000
+	k8s.io/idl/backends/tocrd v0.0.0-00010101000000-000000000000
 	k8s.io/idl/ckdl-ir/goir v0.0.0-00010101000000-000000000000
 )
+
+require (
+	github.com/go-logr/logr v1.2.4 // indirect
+	github.com/gogo/protobuf v1.3.2 // indirect
+	github.com/google/gofuzz v1.2.0 // indirect
+	github.com/json-iterator/go v1.1.12 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/net v0.30.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+	golang.org/x/text v0.19.0 // indirect
+	golang.org/x/tools v0.26.0 // indirect
+	gopkg.in/inf.v0 v0.9.1 // indirect
+	gopkg.in/yaml.v2 v2.4.0 // indirect
+	k8s.io/klog/v2 v2.100.1 // indirect
+	k8s.io/utils v0.0.0-20230406110748-d93618cff8a2 // indirect
+	sigs.k8s.io/controller-tools v0.4.1 // indirect
+	sigs.k8s.io/json v0.0.0-20221116044647-bc3834ca7abd // indirect
+	sigs.k8s.io/structured-merge-diff/v4 v4.2.3 // indirect
+)
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package tokgo
+
+import (
+	"strings"
+
+	"k8s.io/idl/backends/common/request"
+	"k8s.io/idl/backends/common/typegraph"
+	irt "k8s.io/idl/ckdl-ir/goir/types"
+)
+
+type typeKey = typegraph.Name
+
+// typeIndex knows about all the types in the bundle, so that we can figure
+// out what references to them turn into (e.g. for copying or defaulting).
+type typeIndex struct {
+	*typegraph.Graph
+}
+
+func newTypeIndex(gvs map[request.GroupVersion][]request.GroupVersionInfo) *typeIndex {
+	return &typeIndex{Graph: typegraph.New(gvs)}
+}
+
+func keyFor(ref *irt.Reference) typeKey {
+	return typegraph.NameFromRef(ref)
+}
+
+func sameRef(a, b *irt.Reference) bool {
+	return a.Name == b.Name && a.GroupVersion.Group == b.GroupVersion.Group && a.GroupVersion.Version == b.GroupVersion.Version
+}
+
+// funcForRef returns the name of the generated function for the given type
+// with the given prefix (e.g. SetDefaults_ or Validate), which might live in
+// a different package.
+func funcForRef(refs refMaker, ref *irt.Reference, prefix string) string {
+	typeStr := refs.MakeGVRef(ref)
+	if ind := strings.LastIndex(typeStr, "."); ind >= 0 {
+		return typeStr[:ind+1] + prefix + typeStr[ind+1:]
+	}
+	return prefix + typeStr
+}
+
+// scalarType returns the primitive underlying the given field's type, if
+// values of it can be written as Go literals.
+func (idx *typeIndex) scalarType(field *irt.Field) (irt.Primitive_Type, bool) {
+	switch typ := field.Type.(type) {
+	case *irt.Field_Primitive:
+		return scalarPrim(typ.Primitive)
+	case *irt.Field_NamedType:
+		return idx.scalarRef(typ.NamedType)
+	default:
+		return 0, false
+	}
+}
+
+func (idx *typeIndex) scalarRef(ref *irt.Reference) (irt.Primitive_Type, bool) {
+	subtype, known := idx.Subtypes[keyFor(ref)]
+	if !known {
+		return 0, false
+	}
+	switch body := subtype.Type.(type) {
+	case *irt.Subtype_Enum:
+		return irt.Primitive_STRING, true
+	case *irt.Subtype_PrimitiveAlias:
+		return scalarPrim(body.PrimitiveAlias)
+	case *irt.Subtype_ReferenceAlias:
+		return idx.scalarRef(body.ReferenceAlias)
+	default:
+		return 0, false
+	}
+}
+
+func scalarPrim(prim *irt.Primitive) (irt.Primitive_Type, bool) {
+	switch prim.Type {
+	case irt.Primitive_STRING, irt.Primitive_LEGACYINT32, irt.Primitive_INT64, irt.Primitive_BOOL, irt.Primitive_LEGACYFLOAT64:
+		return prim.Type, true
+	default:
+		return 0, false
+	}
+}
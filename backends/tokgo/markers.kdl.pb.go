@@ -4,7 +4,7 @@
 // 	protoc        v3.13.0
 // source: markers.kdl
 
-package main
+package tokgo
 
 import (
 	proto "github.com/golang/protobuf/proto"
@@ -72,14 +72,200 @@ func (x *Name) GetName() string {
 	return ""
 }
 
+type GoPackage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ImportPath string `protobuf:"bytes,1,opt,name=import_path,json=importPath,proto3" json:"import_path,omitempty"`
+	Name       string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GoPackage) Reset() {
+	*x = GoPackage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_markers_kdl_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GoPackage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoPackage) ProtoMessage() {}
+
+func (x *GoPackage) ProtoReflect() protoreflect.Message {
+	mi := &file_markers_kdl_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoPackage.ProtoReflect.Descriptor instead.
+func (*GoPackage) Descriptor() ([]byte, []int) {
+	return file_markers_kdl_rawDescGZIP(), []int{1}
+}
+
+func (x *GoPackage) GetImportPath() string {
+	if x != nil {
+		return x.ImportPath
+	}
+	return ""
+}
+
+func (x *GoPackage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Client struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Scope        string   `protobuf:"bytes,1,opt,name=scope,proto3" json:"scope,omitempty"`
+	Resource     string   `protobuf:"bytes,2,opt,name=resource,proto3" json:"resource,omitempty"`
+	Verbs        []string `protobuf:"bytes,3,rep,name=verbs,proto3" json:"verbs,omitempty"`
+	SkipVerbs    []string `protobuf:"bytes,4,rep,name=skip_verbs,json=skipVerbs,proto3" json:"skip_verbs,omitempty"`
+	Subresources []string `protobuf:"bytes,5,rep,name=subresources,proto3" json:"subresources,omitempty"`
+}
+
+func (x *Client) Reset() {
+	*x = Client{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_markers_kdl_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Client) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Client) ProtoMessage() {}
+
+func (x *Client) ProtoReflect() protoreflect.Message {
+	mi := &file_markers_kdl_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Client.ProtoReflect.Descriptor instead.
+func (*Client) Descriptor() ([]byte, []int) {
+	return file_markers_kdl_rawDescGZIP(), []int{2}
+}
+
+func (x *Client) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *Client) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *Client) GetVerbs() []string {
+	if x != nil {
+		return x.Verbs
+	}
+	return nil
+}
+
+func (x *Client) GetSkipVerbs() []string {
+	if x != nil {
+		return x.SkipVerbs
+	}
+	return nil
+}
+
+func (x *Client) GetSubresources() []string {
+	if x != nil {
+		return x.Subresources
+	}
+	return nil
+}
+
+type NoClient struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *NoClient) Reset() {
+	*x = NoClient{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_markers_kdl_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NoClient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoClient) ProtoMessage() {}
+
+func (x *NoClient) ProtoReflect() protoreflect.Message {
+	mi := &file_markers_kdl_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoClient.ProtoReflect.Descriptor instead.
+func (*NoClient) Descriptor() ([]byte, []int) {
+	return file_markers_kdl_rawDescGZIP(), []int{3}
+}
+
 var File_markers_kdl protoreflect.FileDescriptor
 
 var file_markers_kdl_rawDesc = []byte{
 	0x0a, 0x0b, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x2e, 0x6b, 0x64, 0x6c, 0x12, 0x12, 0x6b,
 	0x62, 0x2e, 0x69, 0x72, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x2e, 0x6b, 0x67,
 	0x6f, 0x22, 0x1a, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x40, 0x0a,
+	0x09, 0x47, 0x6f, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6d,
+	0x70, 0x6f, 0x72, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22,
+	0x93, 0x01, 0x0a, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63,
+	0x6f, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x65, 0x72, 0x62, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x76, 0x65, 0x72,
+	0x62, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x62, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6b, 0x69, 0x70, 0x56, 0x65, 0x72, 0x62,
+	0x73, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x22, 0x0a, 0x0a, 0x08, 0x4e, 0x6f, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -94,9 +280,12 @@ func file_markers_kdl_rawDescGZIP() []byte {
 	return file_markers_kdl_rawDescData
 }
 
-var file_markers_kdl_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_markers_kdl_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_markers_kdl_goTypes = []interface{}{
-	(*Name)(nil), // 0: kb.ir.backends.kgo.Name
+	(*Name)(nil),      // 0: kb.ir.backends.kgo.Name
+	(*GoPackage)(nil), // 1: kb.ir.backends.kgo.GoPackage
+	(*Client)(nil),    // 2: kb.ir.backends.kgo.Client
+	(*NoClient)(nil),  // 3: kb.ir.backends.kgo.NoClient
 }
 var file_markers_kdl_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -124,6 +313,42 @@ func file_markers_kdl_init() {
 				return nil
 			}
 		}
+		file_markers_kdl_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GoPackage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_markers_kdl_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Client); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_markers_kdl_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NoClient); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -131,7 +356,7 @@ func file_markers_kdl_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_markers_kdl_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
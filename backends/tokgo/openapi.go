@@ -0,0 +1,590 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package tokgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"k8s.io/idl/backends/common/typegraph"
+	"k8s.io/idl/backends/tocrd/crd"
+	ir "k8s.io/idl/ckdl-ir/goir"
+	irt "k8s.io/idl/ckdl-ir/goir/types"
+)
+
+// NB(directxman12): openapi-gen reads Go types & comments to produce the
+// definitions aggregated API servers serve.  We produce the same map
+// straight from the IR instead, converting each type with the CRD backend
+// and then reshaping the result the way openapi-gen would: definitions
+// are named after Go types, only structs get definitions (everything else
+// is inlined where it's used), and embedded fields are flattened.
+
+const (
+	objectMetaDef = "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"
+	listMetaDef   = "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"
+)
+
+// openAPIWriter writes the OpenAPI definitions for the types in a
+// group-version.
+type openAPIWriter struct {
+	*pkgWriter
+	errs []error
+}
+
+// openAPIDef is a single definition, with the x-kubernetes-* extensions
+// that don't have a place in a CRD schema.
+type openAPIDef struct {
+	name       string
+	schema     *apiext.JSONSchemaProps
+	extensions map[string]interface{}
+}
+
+// defName returns the OpenAPI definition name of the given type, which is
+// its Go name, as with openapi-gen.
+func (w *openAPIWriter) defName(name typeKey) string {
+	return w.Packages.For(name.GroupVersion).ImportPath + "." + nameType(name.FullName, w.Types.Attributes(name.Ref()))
+}
+
+// convert runs the CRD backend's schema conversion on a kind or subtype,
+// then resolves the references in the result.
+func (w *openAPIWriter) convert(item interface{}) *apiext.JSONSchemaProps {
+	ctx := crd.NewRootContext()
+	var schema *apiext.JSONSchemaProps
+	switch item := item.(type) {
+	case *irt.Kind:
+		schema = crd.KindToSchema(ctx, item)
+	case *irt.Subtype:
+		schema = crd.SubtypeToSchema(ctx, item)
+	default:
+		panic(fmt.Sprintf("unknown item %T", item))
+	}
+	w.errs = append(w.errs, ctx.AllErrors()...)
+	w.resolve(schema)
+	return schema
+}
+
+// resolve rewrites the CRD-style references in the given schema.  Refs
+// to structs, unions, and kinds become refs to their definitions, and
+// refs to everything else are replaced by the schema of what they point
+// to.  Embedded fields (which the CRD backend leaves as allOf refs) get
+// their properties merged into the parent.
+func (w *openAPIWriter) resolve(schema *apiext.JSONSchemaProps) {
+	if schema == nil {
+		return
+	}
+
+	if schema.Ref != nil {
+		// whatever we inline has already been resolved
+		w.resolveRef(schema)
+		return
+	}
+
+	for name, prop := range schema.Properties {
+		w.resolve(&prop)
+		schema.Properties[name] = prop
+	}
+	if schema.Items != nil {
+		w.resolve(schema.Items.Schema)
+	}
+	if schema.AdditionalProperties != nil {
+		w.resolve(schema.AdditionalProperties.Schema)
+	}
+	for i := range schema.OneOf {
+		w.resolve(&schema.OneOf[i])
+	}
+	for i := range schema.AnyOf {
+		w.resolve(&schema.AnyOf[i])
+	}
+
+	var allOf []apiext.JSONSchemaProps
+	for _, sub := range schema.AllOf {
+		if sub.Ref != nil {
+			w.flattenEmbedded(schema, sub)
+			continue
+		}
+		w.resolve(&sub)
+		allOf = append(allOf, sub)
+	}
+	schema.AllOf = allOf
+}
+
+// follow looks up the type named by a CRD reference link.
+func (w *openAPIWriter) follow(link string) (typeKey, typegraph.Terminal, bool) {
+	ident, err := crd.IdentFromRef(link)
+	if err != nil {
+		w.errs = append(w.errs, err)
+		return typeKey{}, nil, false
+	}
+	ref := &irt.Reference{
+		GroupVersion: &irt.GroupVersionRef{Group: ident.Group, Version: ident.Version},
+		Name:         ident.Name,
+	}
+	name, term, known := w.Types.Follow(ref)
+	if !known {
+		// outside the bundle -- assume there's a definition somewhere
+		return keyFor(ref), nil, false
+	}
+	return name, term, true
+}
+
+func (w *openAPIWriter) resolveRef(schema *apiext.JSONSchemaProps) {
+	name, term, known := w.follow(*schema.Ref)
+	switch term.(type) {
+	case typegraph.TerminalStruct, typegraph.TerminalUnion, typegraph.TerminalKind:
+		known = false
+	}
+	if !known {
+		defName := w.defName(name)
+		schema.Ref = &defName
+		return
+	}
+
+	// inline wrappers & enums, keeping the field-level docs & default
+	target := w.convert(w.Types.Subtypes[name])
+	if schema.Description != "" {
+		target.Description = schema.Description
+	}
+	if schema.Default != nil {
+		target.Default = schema.Default
+	}
+	*schema = *target
+}
+
+// flattenEmbedded merges the properties of an embedded type into its parent.
+func (w *openAPIWriter) flattenEmbedded(parent *apiext.JSONSchemaProps, embedded apiext.JSONSchemaProps) {
+	name, term, known := w.follow(*embedded.Ref)
+	if !known {
+		w.errs = append(w.errs, fmt.Errorf("unable to flatten embedded type %s, which is not in the bundle", name))
+		return
+	}
+	var target *apiext.JSONSchemaProps
+	switch term.(type) {
+	case typegraph.TerminalStruct:
+		target = w.convert(w.Types.Subtypes[name])
+	case typegraph.TerminalKind:
+		target = w.convert(w.Types.Kinds[name])
+	default:
+		w.errs = append(w.errs, fmt.Errorf("embedded type %s must be a struct or kind", name))
+		return
+	}
+	for propName, prop := range target.Properties {
+		parent.Properties[propName] = prop
+	}
+	parent.Required = append(parent.Required, target.Required...)
+	parent.XValidations = append(parent.XValidations, target.XValidations...)
+}
+
+// kindDef produces the definition of a kind, filling in the type & object
+// metadata that openapi-gen gets from the embedded meta types.
+func (w *openAPIWriter) kindDef(kind *irt.Kind) openAPIDef {
+	schema := w.convert(kind)
+	typeMetaProps(schema)
+	if kind.Object {
+		schema.Properties["metadata"] = metaProp(objectMetaDef, "Standard object's metadata.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata")
+	} else {
+		delete(schema.Properties, "metadata")
+	}
+	return openAPIDef{
+		name:   w.defName(typeKey{GroupVersion: w.CurrentGV, FullName: kind.Name}),
+		schema: schema,
+	}
+}
+
+// listDef produces the definition of the list type for a persisted kind.
+func (w *openAPIWriter) listDef(kind *irt.Kind) openAPIDef {
+	kindDefName := w.defName(typeKey{GroupVersion: w.CurrentGV, FullName: kind.Name})
+	typeName := nameType(kind.Name, kind.Attributes)
+	schema := &apiext.JSONSchemaProps{
+		Description: fmt.Sprintf("%sList contains a list of %s.", typeName, typeName),
+		Type:        "object",
+		Properties: map[string]apiext.JSONSchemaProps{
+			"metadata": metaProp(listMetaDef, "Standard list metadata.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds"),
+			"items": {
+				Type:  "array",
+				Items: &apiext.JSONSchemaPropsOrArray{Schema: &apiext.JSONSchemaProps{Ref: &kindDefName, Default: &apiext.JSON{Raw: []byte("{}")}}},
+			},
+		},
+		Required: []string{"items"},
+	}
+	typeMetaProps(schema)
+	return openAPIDef{
+		name:   kindDefName + "List",
+		schema: schema,
+	}
+}
+
+// subtypeDef produces the definition of a struct or union.
+func (w *openAPIWriter) subtypeDef(subtype *irt.Subtype) openAPIDef {
+	def := openAPIDef{
+		name:   w.defName(typeKey{GroupVersion: w.CurrentGV, FullName: subtype.Name}),
+		schema: w.convert(subtype),
+	}
+	if union, isUnion := subtype.Type.(*irt.Subtype_Union); isUnion {
+		def.extensions = map[string]interface{}{
+			"x-kubernetes-unions": []interface{}{unionExtension(union.Union)},
+		}
+	}
+	return def
+}
+
+// unionExtension describes a union the way x-kubernetes-unions expects
+// (each variant field is set when the tag holds its name).
+func unionExtension(union *irt.Union) map[string]interface{} {
+	fields := make(map[string]interface{}, len(union.Variants))
+	for _, variant := range union.Variants {
+		fields[variant.Name] = variant.Name
+	}
+	res := map[string]interface{}{
+		"fields-to-discriminateBy": fields,
+	}
+	if !union.Untagged {
+		res["discriminator"] = union.Tag
+	}
+	return res
+}
+
+func typeMetaProps(schema *apiext.JSONSchemaProps) {
+	schema.Properties["apiVersion"] = apiext.JSONSchemaProps{
+		Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+		Type:        "string",
+	}
+	schema.Properties["kind"] = apiext.JSONSchemaProps{
+		Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+		Type:        "string",
+	}
+}
+
+func metaProp(defName, desc string) apiext.JSONSchemaProps {
+	return apiext.JSONSchemaProps{
+		Description: desc,
+		Ref:         &defName,
+		Default:     &apiext.JSON{Raw: []byte("{}")},
+	}
+}
+
+// defFuncName mangles a definition name into a function name, like
+// openapi-gen (e.g. schema_k8sio_api_core_v1_Pod).
+func defFuncName(defName string) string {
+	sep := strings.LastIndex(defName, ".")
+	pkgPath, typeName := defName[:sep], defName[sep+1:]
+	return "schema_" + strings.NewReplacer(".", "", "/", "_", "-", "_").Replace(pkgPath) + "_" + typeName
+}
+
+// schemaWriter writes Go expressions that build spec.Schemas, noting the
+// definitions they reference along the way.
+type schemaWriter struct {
+	spec string
+	deps map[string]bool
+}
+
+// value writes an expression of type spec.Schema.
+func (s *schemaWriter) value(out *bytes.Buffer, schema *apiext.JSONSchemaProps, extensions map[string]interface{}) {
+	builders := s.builders(schema)
+	if builders == "" {
+		s.literal(out, schema, extensions)
+		return
+	}
+	out.WriteString("*(&")
+	s.literal(out, schema, extensions)
+	fmt.Fprintf(out, ")%s", builders)
+}
+
+// pointer writes an expression of type *spec.Schema.
+func (s *schemaWriter) pointer(out *bytes.Buffer, schema *apiext.JSONSchemaProps) {
+	builders := s.builders(schema)
+	if builders == "" {
+		out.WriteString("&")
+		s.literal(out, schema, nil)
+		return
+	}
+	out.WriteString("(&")
+	s.literal(out, schema, nil)
+	fmt.Fprintf(out, ")%s", builders)
+}
+
+// builders returns calls to the spec.Schema builder methods for the
+// validations that are pointers, since those can't be written inline.
+func (s *schemaWriter) builders(schema *apiext.JSONSchemaProps) string {
+	var res strings.Builder
+	intBuilder := func(method string, val *int64) {
+		if val != nil {
+			fmt.Fprintf(&res, ".%s(%d)", method, *val)
+		}
+	}
+	intBuilder("WithMaxLength", schema.MaxLength)
+	intBuilder("WithMinLength", schema.MinLength)
+	intBuilder("WithMaxItems", schema.MaxItems)
+	intBuilder("WithMinItems", schema.MinItems)
+	intBuilder("WithMaxProperties", schema.MaxProperties)
+	intBuilder("WithMinProperties", schema.MinProperties)
+	if schema.Maximum != nil {
+		fmt.Fprintf(&res, ".WithMaximum(%s, %t)", goLiteral(*schema.Maximum), schema.ExclusiveMaximum)
+	}
+	if schema.Minimum != nil {
+		fmt.Fprintf(&res, ".WithMinimum(%s, %t)", goLiteral(*schema.Minimum), schema.ExclusiveMinimum)
+	}
+	if schema.MultipleOf != nil {
+		fmt.Fprintf(&res, ".WithMultipleOf(%s)", goLiteral(*schema.MultipleOf))
+	}
+	return res.String()
+}
+
+func (s *schemaWriter) literal(out *bytes.Buffer, schema *apiext.JSONSchemaProps, extensions map[string]interface{}) {
+	fmt.Fprintf(out, "%s.Schema{\n", s.spec)
+	fmt.Fprintf(out, "SchemaProps: %s.SchemaProps{\n", s.spec)
+	if schema.Description != "" {
+		fmt.Fprintf(out, "Description: %q,\n", schema.Description)
+	}
+	if schema.Type != "" {
+		fmt.Fprintf(out, "Type: []string{%q},\n", schema.Type)
+	}
+	if schema.Format != "" {
+		fmt.Fprintf(out, "Format: %q,\n", schema.Format)
+	}
+	if schema.Default != nil {
+		fmt.Fprintf(out, "Default: %s,\n", jsonLiteral(*schema.Default))
+	}
+	if len(schema.Enum) > 0 {
+		vals := make([]string, len(schema.Enum))
+		for i, val := range schema.Enum {
+			vals[i] = jsonLiteral(val)
+		}
+		fmt.Fprintf(out, "Enum: []interface{}{%s},\n", strings.Join(vals, ", "))
+	}
+	if schema.Pattern != "" {
+		fmt.Fprintf(out, "Pattern: %q,\n", schema.Pattern)
+	}
+	if schema.UniqueItems {
+		fmt.Fprintln(out, "UniqueItems: true,")
+	}
+	if schema.Ref != nil {
+		s.deps[*schema.Ref] = true
+		fmt.Fprintf(out, "Ref: ref(%q),\n", *schema.Ref)
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		fmt.Fprintf(out, "Items: &%s.SchemaOrArray{\nSchema: ", s.spec)
+		s.pointer(out, schema.Items.Schema)
+		fmt.Fprintln(out, ",\n},")
+	}
+	if schema.AdditionalProperties != nil {
+		fmt.Fprintf(out, "AdditionalProperties: &%s.SchemaOrBool{\nAllows: %t,\n", s.spec, schema.AdditionalProperties.Allows)
+		if schema.AdditionalProperties.Schema != nil {
+			out.WriteString("Schema: ")
+			s.pointer(out, schema.AdditionalProperties.Schema)
+			fmt.Fprintln(out, ",")
+		}
+		fmt.Fprintln(out, "},")
+	}
+	if len(schema.Properties) > 0 {
+		propNames := make([]string, 0, len(schema.Properties))
+		for name := range schema.Properties {
+			propNames = append(propNames, name)
+		}
+		sort.Strings(propNames)
+		fmt.Fprintf(out, "Properties: map[string]%s.Schema{\n", s.spec)
+		for _, name := range propNames {
+			prop := schema.Properties[name]
+			fmt.Fprintf(out, "%q: ", name)
+			s.value(out, &prop, nil)
+			fmt.Fprintln(out, ",")
+		}
+		fmt.Fprintln(out, "},")
+	}
+	if len(schema.Required) > 0 {
+		required := make([]string, len(schema.Required))
+		for i, name := range schema.Required {
+			required[i] = strconv.Quote(name)
+		}
+		fmt.Fprintf(out, "Required: []string{%s},\n", strings.Join(required, ", "))
+	}
+	s.schemaList(out, "AllOf", schema.AllOf)
+	s.schemaList(out, "OneOf", schema.OneOf)
+	s.schemaList(out, "AnyOf", schema.AnyOf)
+	fmt.Fprintln(out, "},")
+
+	exts := kubeExtensions(schema)
+	for name, val := range extensions {
+		exts[name] = val
+	}
+	if len(exts) > 0 {
+		extNames := make([]string, 0, len(exts))
+		for name := range exts {
+			extNames = append(extNames, name)
+		}
+		sort.Strings(extNames)
+		fmt.Fprintf(out, "VendorExtensible: %[1]s.VendorExtensible{\nExtensions: %[1]s.Extensions{\n", s.spec)
+		for _, name := range extNames {
+			fmt.Fprintf(out, "%q: %s,\n", name, goLiteral(exts[name]))
+		}
+		fmt.Fprintln(out, "},\n},")
+	}
+	out.WriteString("}")
+}
+
+func (s *schemaWriter) schemaList(out *bytes.Buffer, field string, schemata []apiext.JSONSchemaProps) {
+	if len(schemata) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "%s: []%s.Schema{\n", field, s.spec)
+	for i := range schemata {
+		s.value(out, &schemata[i], nil)
+		fmt.Fprintln(out, ",")
+	}
+	fmt.Fprintln(out, "},")
+}
+
+// kubeExtensions collects the x-kubernetes-* extensions that the CRD
+// schema has dedicated fields for.
+func kubeExtensions(schema *apiext.JSONSchemaProps) map[string]interface{} {
+	exts := make(map[string]interface{})
+	if schema.XIntOrString {
+		exts["x-kubernetes-int-or-string"] = true
+	}
+	if schema.XPreserveUnknownFields != nil {
+		exts["x-kubernetes-preserve-unknown-fields"] = *schema.XPreserveUnknownFields
+	}
+	if schema.XEmbeddedResource {
+		exts["x-kubernetes-embedded-resource"] = true
+	}
+	if schema.XListType != nil {
+		exts["x-kubernetes-list-type"] = *schema.XListType
+	}
+	if len(schema.XListMapKeys) > 0 {
+		keys := make([]interface{}, len(schema.XListMapKeys))
+		for i, key := range schema.XListMapKeys {
+			keys[i] = key
+		}
+		exts["x-kubernetes-list-map-keys"] = keys
+	}
+	if schema.XMapType != nil {
+		exts["x-kubernetes-map-type"] = *schema.XMapType
+	}
+	if len(schema.XValidations) > 0 {
+		rules := make([]interface{}, len(schema.XValidations))
+		for i, rule := range schema.XValidations {
+			ruleExt := map[string]interface{}{"rule": rule.Rule}
+			if rule.Message != "" {
+				ruleExt["message"] = rule.Message
+			}
+			if rule.FieldPath != "" {
+				ruleExt["fieldPath"] = rule.FieldPath
+			}
+			rules[i] = ruleExt
+		}
+		exts["x-kubernetes-validations"] = rules
+	}
+	return exts
+}
+
+// jsonLiteral writes a serialized JSON value as a Go literal.
+func jsonLiteral(raw apiext.JSON) string {
+	var val interface{}
+	if err := json.Unmarshal(raw.Raw, &val); err != nil {
+		// the CRD backend produced these, so they should always be valid
+		panic(fmt.Sprintf("invalid JSON value %q: %v", raw.Raw, err))
+	}
+	return goLiteral(val)
+}
+
+// goLiteral writes a JSON-ish value as a Go literal, for use as an
+// interface{}.
+func goLiteral(val interface{}) string {
+	switch val := val.(type) {
+	case nil:
+		return "nil"
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	case string:
+		return strconv.Quote(val)
+	case []interface{}:
+		items := make([]string, len(val))
+		for i, item := range val {
+			items[i] = goLiteral(item)
+		}
+		return "[]interface{}{" + strings.Join(items, ", ") + "}"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		items := make([]string, len(keys))
+		for i, key := range keys {
+			items[i] = strconv.Quote(key) + ": " + goLiteral(val[key])
+		}
+		return "map[string]interface{}{" + strings.Join(items, ", ") + "}"
+	default:
+		panic(fmt.Sprintf("unexpected literal value %#v", val))
+	}
+}
+
+// writeOpenAPI writes GetOpenAPIDefinitions for the given group-version,
+// covering its kinds (and their lists), structs, and unions.
+func writeOpenAPI(inputs []*ir.GroupVersion, out *openAPIWriter) error {
+	fmt.Fprint(out.header, "// Code generated by ckdl-to-tokgo. DO NOT EDIT.\n\n")
+	out.Package(comment{})
+	common := out.Import("k8s.io/kube-openapi/pkg/common", "common")
+	specs := &schemaWriter{spec: out.Import("k8s.io/kube-openapi/pkg/validation/spec", "spec")}
+
+	var defs []openAPIDef
+	for _, gv := range inputs {
+		for _, kind := range gv.Kinds {
+			defs = append(defs, out.kindDef(kind))
+			if kind.Object {
+				defs = append(defs, out.listDef(kind))
+			}
+		}
+		for _, subtype := range gv.Types {
+			switch subtype.Type.(type) {
+			case *irt.Subtype_Struct, *irt.Subtype_Union:
+				defs = append(defs, out.subtypeDef(subtype))
+			}
+		}
+	}
+	if len(out.errs) > 0 {
+		return fmt.Errorf("unable to convert types to OpenAPI schemata: %v", out.errs)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].name < defs[j].name })
+
+	getter := new(bytes.Buffer)
+	fmt.Fprintln(getter, "// GetOpenAPIDefinitions returns the OpenAPI definitions for the types in this package, keyed by their Go names.")
+	fmt.Fprintf(getter, "func GetOpenAPIDefinitions(ref %[1]s.ReferenceCallback) map[string]%[1]s.OpenAPIDefinition {\n", common)
+	fmt.Fprintf(getter, "return map[string]%s.OpenAPIDefinition{\n", common)
+	for _, def := range defs {
+		fmt.Fprintf(getter, "%q: %s(ref),\n", def.name, defFuncName(def.name))
+	}
+	fmt.Fprintln(getter, "}\n}")
+	out.Code("GetOpenAPIDefinitions", getter)
+
+	for _, def := range defs {
+		specs.deps = make(map[string]bool)
+		body := new(bytes.Buffer)
+		specs.value(body, def.schema, def.extensions)
+
+		deps := make([]string, 0, len(specs.deps))
+		for dep := range specs.deps {
+			deps = append(deps, strconv.Quote(dep))
+		}
+		sort.Strings(deps)
+
+		fn := new(bytes.Buffer)
+		fmt.Fprintf(fn, "func %s(ref %[2]s.ReferenceCallback) %[2]s.OpenAPIDefinition {\n", defFuncName(def.name), common)
+		fmt.Fprintf(fn, "return %s.OpenAPIDefinition{\nSchema: ", common)
+		body.WriteTo(fn)
+		fmt.Fprintln(fn, ",")
+		if len(deps) > 0 {
+			fmt.Fprintf(fn, "Dependencies: []string{\n%s,\n},\n", strings.Join(deps, ",\n"))
+		}
+		fmt.Fprintln(fn, "}\n}")
+		out.Code(defFuncName(def.name), fn)
+	}
+	return nil
+}
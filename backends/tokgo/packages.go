@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package tokgo
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"k8s.io/idl/backends/common/request"
+)
+
+//go:generate kdlc -i . -o markerproto markers.kdl
+//go:generate protoc --descriptor_set_in=markers.kdl.desc --go_out=. --go_opt=Mmarkers.kdl=.;tokgo markers.kdl
+
+// goPackage is the Go package that a group-version is generated into.
+// Empty fields take their defaults (see goPackages.For).
+type goPackage struct {
+	ImportPath string
+	Name       string
+}
+
+// goPackages maps group-versions to the Go packages they're generated into.
+type goPackages map[groupVersion]goPackage
+
+// For returns the package for the given group-version, filling in defaults
+// for anything that wasn't configured.
+func (p goPackages) For(gv groupVersion) goPackage {
+	pkg := p[gv]
+	if pkg.ImportPath == "" {
+		switch gv.Group {
+		case "__resource":
+			pkg.ImportPath = "k8s.io/apimachinery/pkg/api/resource"
+		case "__intstr":
+			pkg.ImportPath = "k8s.io/apimachinery/pkg/util/intstr"
+		case "meta.k8s.io":
+			pkg.ImportPath = fmt.Sprintf("k8s.io/apimachinery/pkg/apis/meta/%s", gv.Version)
+		default:
+			pkg.ImportPath = fmt.Sprintf("k8s.io/api/%s/%s", gv.Group, gv.Version)
+		}
+	}
+	if pkg.Name == "" {
+		pkg.Name = gv.Version
+	}
+	return pkg
+}
+
+// loadGoPackages collects the go-package markers from every group-version
+// in the bundle, then applies the go-package options on top of them.
+func loadGoPackages(groupVersions map[request.GroupVersion][]request.GroupVersionInfo, optVals []string) (goPackages, error) {
+	res := make(goPackages)
+	for gv, infos := range groupVersions {
+		key := groupVersion{Group: gv.Group, Version: gv.Version}
+		for _, info := range infos {
+			for _, attr := range info.GroupVersion.Description.Attributes {
+				if !attr.MessageIs(&GoPackage{}) {
+					continue
+				}
+				var marker GoPackage
+				if err := attr.UnmarshalTo(&marker); err != nil {
+					return nil, fmt.Errorf("unable to decode go-package marker on %s: %w", gv, err)
+				}
+				pkg := goPackage{ImportPath: marker.ImportPath, Name: marker.Name}
+				if existing, exists := res[key]; exists && existing != pkg {
+					return nil, fmt.Errorf("conflicting go-package markers on %s in %s", gv, info.OriginalName)
+				}
+				res[key] = pkg
+			}
+		}
+	}
+
+	for _, optVal := range optVals {
+		gv, pkg, err := parseGoPackageFlag(optVal)
+		if err != nil {
+			return nil, err
+		}
+		res[gv] = pkg
+	}
+
+	for gv, pkg := range res {
+		if pkg.Name != "" && !token.IsIdentifier(pkg.Name) {
+			return nil, fmt.Errorf("invalid Go package name %q for %s/%s", pkg.Name, gv.Group, gv.Version)
+		}
+	}
+	return res, nil
+}
+
+// parseGoPackageFlag parses a go-package option, of the form
+// `group/version=import/path`, optionally followed by `:name` to set the
+// package name as well.
+func parseGoPackageFlag(raw string) (groupVersion, goPackage, error) {
+	parts := strings.SplitN(raw, "=", 2)
+	if len(parts) != 2 {
+		return groupVersion{}, goPackage{}, fmt.Errorf("invalid go-package option %q, expected group/version=import/path[:name]", raw)
+	}
+	gvParts := strings.Split(parts[0], "/")
+	if len(gvParts) != 2 || gvParts[0] == "" || gvParts[1] == "" {
+		return groupVersion{}, goPackage{}, fmt.Errorf("invalid group-version %q in go-package option %q", parts[0], raw)
+	}
+	var pkg goPackage
+	pathParts := strings.SplitN(parts[1], ":", 2)
+	pkg.ImportPath = pathParts[0]
+	if len(pathParts) == 2 {
+		pkg.Name = pathParts[1]
+	}
+	return groupVersion{Group: gvParts[0], Version: gvParts[1]}, pkg, nil
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package tokgo
+
+import (
+	"bytes"
+	"fmt"
+
+	ir "k8s.io/idl/ckdl-ir/goir"
+	irt "k8s.io/idl/ckdl-ir/goir/types"
+)
+
+// writeRegister writes the scheme registration boilerplate for the given
+// group-version, like the register.go files in k8s.io/api.
+func writeRegister(inputs []*ir.GroupVersion, out *pkgWriter) {
+	fmt.Fprint(out.header, "// Code generated by ckdl-to-tokgo. DO NOT EDIT.\n\n")
+	desc := inputs[0].Description
+	out.Package(comment{})
+
+	runtime := out.Import("k8s.io/apimachinery/pkg/runtime", "runtime")
+	schema := out.Import("k8s.io/apimachinery/pkg/runtime/schema", "schema")
+	addToGroupVersion := out.MakeGVRef(metaRef("AddToGroupVersion"))
+
+	vars := new(bytes.Buffer)
+	fmt.Fprintln(vars, "// GroupName is the group name used in this package.")
+	fmt.Fprintf(vars, "const GroupName = %q\n\n", desc.Group)
+	fmt.Fprintln(vars, "// SchemeGroupVersion is group version used to register these objects.")
+	fmt.Fprintf(vars, "var SchemeGroupVersion = %s.GroupVersion{Group: GroupName, Version: %q}\n\n", schema, desc.Version)
+	fmt.Fprintln(vars, "// Resource takes an unqualified resource and returns a Group qualified GroupResource.")
+	fmt.Fprintf(vars, "func Resource(resource string) %s.GroupResource {\n", schema)
+	fmt.Fprintln(vars, "return SchemeGroupVersion.WithResource(resource).GroupResource()\n}")
+	fmt.Fprintln(vars, "")
+	fmt.Fprintln(vars, "var (")
+	fmt.Fprintln(vars, "// SchemeBuilder collects the functions that add this group-version's types (and their defaults) to a scheme.")
+	fmt.Fprintf(vars, "SchemeBuilder = %s.NewSchemeBuilder(addKnownTypes, RegisterDefaults)\n", runtime)
+	fmt.Fprintln(vars, "localSchemeBuilder = &SchemeBuilder")
+	fmt.Fprintln(vars, "// AddToScheme adds all types of this group-version to the given scheme.")
+	fmt.Fprintln(vars, "AddToScheme = localSchemeBuilder.AddToScheme")
+	fmt.Fprintln(vars, ")")
+	out.Code("SchemeGroupVersion", vars)
+
+	known := new(bytes.Buffer)
+	fmt.Fprintln(known, "// addKnownTypes adds the list of known types to the given scheme.")
+	fmt.Fprintf(known, "func addKnownTypes(scheme *%s.Scheme) error {\n", runtime)
+	fmt.Fprintln(known, "scheme.AddKnownTypes(SchemeGroupVersion,")
+	for _, gv := range inputs {
+		for _, kind := range gv.Kinds {
+			typeName := nameType(kind.Name, kind.Attributes)
+			fmt.Fprintf(known, "&%s{},\n", typeName)
+			if kind.Object {
+				fmt.Fprintf(known, "&%sList{},\n", typeName)
+			}
+		}
+	}
+	fmt.Fprintln(known, ")")
+	fmt.Fprintf(known, "%s(scheme, SchemeGroupVersion)\n", addToGroupVersion)
+	fmt.Fprintln(known, "return nil\n}")
+	out.Code("addKnownTypes", known)
+}
+
+// writeDoc writes the package docs for the given group-version, along with
+// the +groupName marker that other generators look for.
+func writeDoc(inputs []*ir.GroupVersion, out *pkgWriter) {
+	fmt.Fprint(out.header, "// Code generated by ckdl-to-tokgo. DO NOT EDIT.\n\n")
+	desc := inputs[0].Description
+
+	writeComments(&comment{markers: []marker{{name: "groupName", value: desc.Group}}}, out.header)
+	fmt.Fprintln(out.header, "")
+
+	docs := &irt.Documentation{}
+	for _, gv := range inputs {
+		if gv.Description.Docs != nil {
+			docs.Description += gv.Description.Docs.Description
+			docs.ExternalRef += gv.Description.Docs.ExternalRef
+			docs.Example += gv.Description.Docs.Example
+		}
+	}
+	if docs.Description == "" {
+		pkgName := out.Packages.For(out.CurrentGV).Name
+		docs.Description = fmt.Sprintf("Package %s contains the %s version of the %s API group.", pkgName, desc.Version, desc.Group)
+	}
+	out.Package(comment{doc: docs})
+}
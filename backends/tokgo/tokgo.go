@@ -1,8 +1,9 @@
 // SPDX-License-Identifier: Apache-2.0
 // Copyright 2021 The Kubernetes Authors
-package main
+package tokgo
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"bytes"
@@ -10,10 +11,16 @@ import (
 	"sort"
 	gofmt "go/format"
 	"path"
+	"encoding/json"
+	"regexp"
 
+	"k8s.io/idl/backends/common/backend"
 	"k8s.io/idl/backends/common/request"
 	"k8s.io/idl/backends/common/respond"
+	"k8s.io/idl/backends/common/sdk"
+	"k8s.io/idl/backends/common/typegraph"
 	pany "github.com/golang/protobuf/ptypes/any"
+	"google.golang.org/protobuf/proto"
 
 
 	ir "k8s.io/idl/ckdl-ir/goir"
@@ -36,11 +43,11 @@ type recordedType struct {
 	contents *bytes.Buffer
 	constBlock *bytes.Buffer
 }
-type groupVersion struct {
-	Group, Version string
-}
+type groupVersion = request.GroupVersion
 type imports struct {
 	byGV map[groupVersion]string
+	// byPath holds non-KDL imports (e.g. apimachinery's runtime package)
+	byPath map[string]string
 	used map[string]struct{}
 }
 
@@ -48,16 +55,36 @@ type pkgWriter struct {
 	Boilerplate string
 	SortOrder map[string]int
 	CurrentGV groupVersion
+	// Packages determines the import paths & package names for the
+	// group-versions referenced by (and including) CurrentGV.
+	Packages goPackages
+	// Types is used to look up the markers on referenced types.
+	Types *typegraph.Graph
+	// PruneImports skips imports that aren't referenced by the written code,
+	// for files that only mention some of the types they compute.
+	PruneImports bool
+	// ImportCurrent makes references to CurrentGV's types go through an
+	// import too, for files that live outside of CurrentGV's package (like
+	// apply configurations).
+	ImportCurrent bool
+
+	// Resp receives errors found while writing, and the finished files.
+	Resp *respond.Responder
 
 	imports imports
 	header *bytes.Buffer
 	types []recordedType
 	typeInds map[string]int
 }
-func newPkgWriter() *pkgWriter {
+func newPkgWriter(gv groupVersion, packages goPackages, types *typegraph.Graph, resp *respond.Responder) *pkgWriter {
 	return &pkgWriter{
+		CurrentGV: gv,
+		Packages: packages,
+		Types: types,
+		Resp: resp,
 		imports: imports{
 			byGV: make(map[groupVersion]string),
+			byPath: make(map[string]string),
 			used: make(map[string]struct{}),
 		},
 		header: new(bytes.Buffer),
@@ -97,21 +124,24 @@ func (w *pkgWriter) Write(out io.Writer) {
 
 	io.Copy(out, w.header)
 
-	fmt.Fprintln(out, "import (")
+	var imports bytes.Buffer
 	for gv, alias := range w.imports.byGV {
-		// TODO: figure out import paths
-		var path string
-		switch gv.Group {
-		case "__resource":
-			path =  "k8s.io/apimachinery/pkg/api/resource"
-		case "__intstr":
-			path = "k8s.io/apimachinery/pkg/util/intstr"
-		default:
-			path = fmt.Sprintf("k8s.io/api/%s/%v", gv.Group, gv.Version)
+		if w.PruneImports && !w.usesImport(alias) {
+			continue
+		}
+		fmt.Fprintf(&imports, "\t%s %q\n", alias, w.Packages.For(gv).ImportPath)
+	}
+	for path, alias := range w.imports.byPath {
+		if w.PruneImports && !w.usesImport(alias) {
+			continue
 		}
-		fmt.Fprintf(out, "\t%s %q\n", alias, path)
+		fmt.Fprintf(&imports, "\t%s %q\n", alias, path)
+	}
+	if imports.Len() > 0 {
+		fmt.Fprintln(out, "import (")
+		io.Copy(out, &imports)
+		fmt.Fprint(out, ")\n\n")
 	}
-	fmt.Fprintln(out, ")\n")
 
 	if w.SortOrder != nil {
 		sort.Slice(w.types, func(i, j int) bool {
@@ -129,10 +159,19 @@ func (w *pkgWriter) Write(out io.Writer) {
 		fmt.Fprintln(out, "")
 	}
 }
-func (w *pkgWriter) Package(nameHint string, comments comment) {
-	// TODO: allow overriding name, etc
+func (w *pkgWriter) usesImport(alias string) bool {
+	// aliases can be suffixes of other aliases (e.g. av1 & applymetav1)
+	usage := regexp.MustCompile(`\b`+regexp.QuoteMeta(alias)+`\.`)
+	for _, typ := range w.types {
+		if usage.Match(typ.contents.Bytes()) {
+			return true
+		}
+	}
+	return false
+}
+func (w *pkgWriter) Package(comments comment) {
 	writeComments(&comments, w.header)
-	fmt.Fprintf(w.header, "package %s\n\n", nameHint)
+	fmt.Fprintf(w.header, "package %s\n\n", w.Packages.For(w.CurrentGV).Name)
 }
 func (w *pkgWriter) BlockType(name string, comments comment, cb func(*subWriter)) {
 	out := new(bytes.Buffer)
@@ -154,6 +193,13 @@ func (w *pkgWriter) LineType(name string, comments comment, typeStr string) {
 	w.typeInds[name] = len(w.types)
 	w.types = append(w.types, recordedType{name: name, contents: out})
 }
+// Code records arbitrary top-level code (e.g. functions) associated with
+// the given type, for files that don't contain the type definitions
+// themselves.
+func (w *pkgWriter) Code(name string, contents *bytes.Buffer) {
+	w.typeInds[name] = len(w.types)
+	w.types = append(w.types, recordedType{name: name, contents: contents})
+}
 type enumConst struct {
 	name string
 	value string
@@ -169,9 +215,12 @@ func (w *pkgWriter) ConstBlock(typeName string, variants ...enumConst) {
 	fmt.Fprintln(out, ")")
 	w.types[w.typeInds[typeName]].constBlock = out
 }
+func (w *pkgWriter) responder() *respond.Responder {
+	return w.Resp
+}
 func (w *pkgWriter) MakeGVRef(ref *irt.Reference) string {
-	typeName := nameType(ref.Name, nil) // TODO: we need the typecheck graph to get the underlying markers for this
-	if ref.GroupVersion.Group == w.CurrentGV.Group && ref.GroupVersion.Version == w.CurrentGV.Version {
+	typeName := nameType(ref.Name, w.Types.Attributes(ref))
+	if !w.ImportCurrent && ref.GroupVersion.Group == w.CurrentGV.Group && ref.GroupVersion.Version == w.CurrentGV.Version {
 		return typeName
 	}
 	gv := groupVersion{Group: ref.GroupVersion.Group, Version: ref.GroupVersion.Version}
@@ -179,7 +228,16 @@ func (w *pkgWriter) MakeGVRef(ref *irt.Reference) string {
 		return existing+"."+typeName
 	}
 
-	// first try combinations of group+version, starting with just the first
+	// prefer an explicitly configured package name, if it's free
+	if name := w.Packages[gv].Name; name != "" {
+		if _, exists := w.imports.used[name]; !exists {
+			w.imports.used[name] = struct{}{}
+			w.imports.byGV[gv] = name
+			return name+"."+typeName
+		}
+	}
+
+	// otherwise, try combinations of group+version, starting with just the first
 	// part, then going forward.
 	groupParts := strings.Split(gv.Group, ".")
 	groupParts[0] = strings.TrimPrefix(groupParts[0], "__")
@@ -205,6 +263,16 @@ func (w *pkgWriter) MakeGVRef(ref *irt.Reference) string {
 		}
 	}
 }
+// Import records an import of a non-KDL package, returning the alias
+// to use to refer to it.
+func (w *pkgWriter) Import(path, alias string) string {
+	if existing, exists := w.imports.byPath[path]; exists {
+		return existing
+	}
+	w.imports.used[alias] = struct{}{}
+	w.imports.byPath[path] = alias
+	return alias
+}
 type subWriter struct {
 	parent *pkgWriter
 	out *bytes.Buffer
@@ -232,6 +300,9 @@ func (w *subWriter) Field(name string, typ string, structTag tags, comments comm
 func (w *subWriter) MakeGVRef(ref *irt.Reference) string {
 	return w.parent.MakeGVRef(ref)
 }
+func (w *subWriter) responder() *respond.Responder {
+	return w.parent.Resp
+}
 type tag []string
 type tags struct {
 	json tag
@@ -243,73 +314,217 @@ func (t tags) empty() bool {
 	return len(t.json) == 0 && len(t.proto) == 0 && t.patchStrategy == "" && t.patchMergeKey == ""
 }
 
-func main() {
-	loader, types := request.Parse()
-	if len(types) != 0 {
-		panic("TODO: support generating only for specific types")
+// Backend is the Go types backend.
+var Backend backend.Backend = backend.Func(generate)
+
+func generate(_ context.Context, req *request.Request, resp *respond.Responder) error {
+	loader, opts := req.Loader, req.Options
+	resp.Debug("beginning")
+	if err := opts.CheckKnown("go-package", "apply-configurations", "clients", "openapi"); err != nil {
+		return err
+	}
+
+	// the types we generate have to compile, so we need everything they
+	// refer to as well
+	bundle := sdk.Load(req)
+	requested, err := bundle.Requested()
+	if err != nil {
+		return err
+	}
+	selected := bundle.Closure(requested)
+
+	packages, err := loadGoPackages(loader.GroupVersions(), opts.Strings("go-package"))
+	if err != nil {
+		return fmt.Errorf("unable to determine Go packages: %w", err)
+	}
+
+	applyConfigs, err := opts.Bool("apply-configurations", false)
+	if err != nil {
+		return err
+	}
+	clients, err := opts.Bool("clients", false)
+	if err != nil {
+		return err
+	}
+	openAPI, err := opts.Bool("openapi", false)
+	if err != nil {
+		return err
 	}
-	respond.GeneralInfo("beginning")
 
+	index := newTypeIndex(loader.GroupVersions())
+	needed := defaultedTypes(index)
 	for gv, infos := range loader.GroupVersions() {
-		respond.GeneralInfo("processing group-version", "group", gv.Group, "version", gv.Version)
-		out := newPkgWriter() // TODO
-		out.CurrentGV = groupVersion{Group: gv.Group, Version: gv.Version}
 		irs := make([]*ir.GroupVersion, len(infos))
 		for i, info := range infos {
-			irs[i] = info.GroupVersion
+			irs[i] = selectedIR(gv, info.GroupVersion, selected)
 		}
+		if len(req.Types) > 0 && !hasTypes(irs) {
+			// nothing we were asked for needs this group-version
+			continue
+		}
+		resp.Debug("processing group-version", "group", gv.Group, "version", gv.Version)
+		newWriter := func() *pkgWriter {
+			return newPkgWriter(gv, packages, index.Graph, resp)
+		}
+		out := newWriter()
 		writeGo(irs, out)
 
-		rawSrc := out.Bytes()
-		fmtSrc, err := gofmt.Source(rawSrc)
-		if err != nil {
-			respond.GeneralError(err, "unable to gofmt file", "group", gv.Group, "version", gv.Version)
-			fmtSrc = rawSrc
-		}
 		// everything is path, not filepath, until we read to/write from disk
 		// TODO: check that path is actually the canonical format everywhere
 		// TODO: marker to override this
 		// TODO: flag to override this
 		outFileName := path.Join(path.Dir(infos[0].OriginalName), "types.go")
 		if len(infos) > 1 {
-			respond.GeneralInfo("multiple source KDL files for group-version, using first for output name", "group", gv.Group, "version", gv.Version)
+			resp.Warn("multiple source KDL files for group-version, using first for output name", "group", gv.Group, "version", gv.Version)
 		}
-		respond.File(outFileName, fmtSrc)
-	}
+		writeGoFile(outFileName, out, gv)
+
+		deepCopyOut := &deepCopyWriter{pkgWriter: newWriter(), index: index}
+		writeDeepCopy(irs, deepCopyOut)
+		writeGoFile(path.Join(path.Dir(outFileName), "zz_generated.deepcopy.go"), deepCopyOut.pkgWriter, gv)
+
+		defaultsOut := &defaultsWriter{pkgWriter: newWriter(), index: index, needed: needed}
+		writeDefaults(irs, defaultsOut)
+		writeGoFile(path.Join(path.Dir(outFileName), "zz_generated.defaults.go"), defaultsOut.pkgWriter, gv)
+
+		registerOut := newWriter()
+		writeRegister(irs, registerOut)
+		writeGoFile(path.Join(path.Dir(outFileName), "register.go"), registerOut, gv)
+
+		docOut := newWriter()
+		writeDoc(irs, docOut)
+		writeGoFile(path.Join(path.Dir(outFileName), "doc.go"), docOut, gv)
+
+		validationOut := &validationWriter{pkgWriter: newWriter(), index: index, patterns: make(map[string]string)}
+		writeValidation(irs, validationOut)
+		writeGoFile(path.Join(path.Dir(outFileName), "zz_generated.validations.go"), validationOut.pkgWriter, gv)
 
+		if applyConfigs {
+			applyOut := &applyWriter{pkgWriter: newWriter(), index: index}
+			if err := writeApplyFiles(irs, path.Dir(outFileName), applyOut, newWriter()); err != nil {
+				resp.GeneralError(err, "unable to generate apply configurations", "group", gv.Group, "version", gv.Version)
+			}
+		}
+
+		if clients {
+			var kinds []clientKind
+			for _, gvIR := range irs {
+				for _, kind := range gvIR.Kinds {
+					clientKind, hasClient, err := clientKindFor(kind, applyConfigs)
+					if err != nil {
+						resp.GeneralError(err, "unable to configure client", "group", gv.Group, "version", gv.Version)
+						continue
+					}
+					if hasClient {
+						kinds = append(kinds, clientKind)
+					}
+				}
+			}
+			writeClientFiles(kinds, path.Dir(outFileName), func() *clientWriter {
+				return newClientWriter(newWriter())
+			})
+		}
+
+		if openAPI {
+			openAPIOut := &openAPIWriter{pkgWriter: newWriter()}
+			if err := writeOpenAPI(irs, openAPIOut); err != nil {
+				resp.GeneralError(err, "unable to generate OpenAPI definitions", "group", gv.Group, "version", gv.Version)
+			} else {
+				writeGoFile(path.Join(path.Dir(outFileName), "zz_generated.openapi.go"), openAPIOut.pkgWriter, gv)
+			}
+		}
+	}
+	return nil
 }
 
-func writeGo(inputs []*ir.GroupVersion, out *pkgWriter) {
-	pkgComments := comment{
-		doc: &irt.Documentation{},
+// selectedIR returns the given group-version with only the selected types
+// in it.  The result shares its types with the request, so that logs can
+// still point at them.
+func selectedIR(gv request.GroupVersion, gvIR *ir.GroupVersion, selected *sdk.Selection) *ir.GroupVersion {
+	res := &ir.GroupVersion{Description: gvIR.Description}
+	for _, kind := range gvIR.Kinds {
+		if selected.Has(typegraph.Name{GroupVersion: gv, FullName: kind.Name}) {
+			res.Kinds = append(res.Kinds, kind)
+		}
 	}
-	for _, gv := range inputs {
-		if gv.Description.Docs != nil {
-			pkgComments.doc.Description += gv.Description.Docs.Description
-			pkgComments.doc.ExternalRef += gv.Description.Docs.ExternalRef
-			pkgComments.doc.Example += gv.Description.Docs.Example
+	for _, subtype := range gvIR.Types {
+		if selected.Has(typegraph.Name{GroupVersion: gv, FullName: subtype.Name}) {
+			res.Types = append(res.Types, subtype)
+		}
+	}
+	return res
+}
+
+func hasTypes(irs []*ir.GroupVersion) bool {
+	for _, gvIR := range irs {
+		if len(gvIR.Kinds) > 0 || len(gvIR.Types) > 0 {
+			return true
 		}
 	}
-	out.Package(inputs[0].Description.Version, pkgComments)
+	return false
+}
+
+// writeGoFile formats the contents of the given writer & sends them back
+// as a file.  Unformattable files are still written, to aid in debugging.
+func writeGoFile(name string, out *pkgWriter, gv request.GroupVersion) {
+	rawSrc := out.Bytes()
+	fmtSrc, err := gofmt.Source(rawSrc)
+	if err != nil {
+		out.Resp.GeneralError(err, "unable to gofmt file", "group", gv.Group, "version", gv.Version, "file", name)
+		fmtSrc = rawSrc
+	}
+	out.Resp.File(name, fmtSrc)
+}
+
+func writeGo(inputs []*ir.GroupVersion, out *pkgWriter) {
+	// package docs live in doc.go (see writeDoc)
+	out.Package(comment{})
 
 	for _, gv := range inputs {
 		for _, kind := range gv.Kinds {
 			typeName := nameType(kind.Name, kind.Attributes)
 			out.BlockType(typeName, comment{isKind: true, doc: kind.Docs}, func(out *subWriter) {
-				out.Field("", "metav1.TypeMeta", tags{json: tag{"", "inline"}}, comment{})
-				out.Field("", "metav1.ObjectMeta", tags{
+				out.Field("", out.MakeGVRef(metaRef("TypeMeta")), tags{json: tag{"", "inline"}}, comment{})
+				if kind.Object {
+					out.Field("", out.MakeGVRef(metaRef("ObjectMeta")), tags{
+						json: tag{"metadata","omitempty"},
+						proto: tag{"bytes", "1", "opt", "name=metadata"},
+					}, comment{
+						doc: &irt.Documentation{
+							Description: "Standard object's metadata.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata",
+						},
+						markers: []marker{{name: "optional"}},
+					})
+				}
+
+				for _, field := range kind.Fields {
+					writeField(field, out)
+				}
+			})
+			if !kind.Object {
+				// nonpersisted kinds are never listed
+				continue
+			}
+			out.BlockType(typeName+"List", comment{
+				isKind: true,
+				doc: &irt.Documentation{
+					Description: fmt.Sprintf("%sList contains a list of %s.", typeName, typeName),
+				},
+			}, func(out *subWriter) {
+				out.Field("", out.MakeGVRef(metaRef("TypeMeta")), tags{json: tag{"", "inline"}}, comment{})
+				out.Field("", out.MakeGVRef(metaRef("ListMeta")), tags{
 					json: tag{"metadata","omitempty"},
 					proto: tag{"bytes", "1", "opt", "name=metadata"},
 				}, comment{
 					doc: &irt.Documentation{
-						Description: "Standard object's metadata.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata",
+						Description: "Standard list metadata.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
 					},
 					markers: []marker{{name: "optional"}},
 				})
-
-				for _, field := range kind.Fields {
-					writeField(field, out)
-				}
+				out.Field("Items", "[]"+typeName, tags{
+					json: tag{"items"},
+					proto: tag{"bytes", "2", "rep", "name=items"},
+				}, comment{})
 			})
 		}
 		for _, subtype := range gv.Types {
@@ -330,20 +545,27 @@ func writeGo(inputs []*ir.GroupVersion, out *pkgWriter) {
 						goTagName := strings.Title(union.Tag)
 						out.Field(goTagName, tagType, tags{json: tag{union.Tag}, proto: tag{"bytes", "1"}}, comment{})
 					}
-					for _, field := range union.Variants {
+					for _, field := range unionVariants(union) {
 						writeField(field, out)
 					}
 				})
 				if !union.Untagged {
+					// include the tag in the names, so that they don't
+					// clash with types named after the union & a variant
+					// (e.g. a SourceHost struct for a Source union's host)
+					constPrefix := typeName+strings.Title(union.Tag)
 					variants := make([]enumConst, len(union.Variants))
 					for i, field := range union.Variants {
 						fieldName := nameField(field.Name, field.Attributes)
 						variants[i] = enumConst{
-							name: typeName+fieldName,
-							value: fieldName,
+							name: constPrefix+fieldName,
+							// must match the serialized field name (see
+							// the oneOf in the CRD schema)
+							value: field.Name,
 							comment: comment{doc: field.Docs},
 						}
 					}
+					out.LineType(tagType, comment{}, "string")
 					out.ConstBlock(tagType, variants...)
 				}
 			case *irt.Subtype_Struct:
@@ -432,7 +654,7 @@ var (
 			Group: "__resource",
 			Version: "",
 		},
-		Name: "Duration",
+		Name: "Quantity",
 	}
 
 	intStrRef = &irt.Reference{
@@ -440,11 +662,20 @@ var (
 			Group: "__intstr",
 			Version: "",
 		},
-		Name: "Duration",
+		Name: "IntOrString",
 	}
 )
 
 
+// metaRef references one of the types from apimachinery's meta/v1 package
+// (e.g. ObjectMeta), which we treat as if they came from KDL.
+func metaRef(name string) *irt.Reference {
+	return &irt.Reference{
+		GroupVersion: timeRef.GroupVersion,
+		Name: name,
+	}
+}
+
 func primType(typ *irt.Primitive, isPtr bool, refs refMaker) string {
 	typeStr := ""
 	switch typ.Type {
@@ -484,7 +715,7 @@ func primType(typ *irt.Primitive, isPtr bool, refs refMaker) string {
 			typeStr = "*"+typeStr
 		}
 	case irt.Primitive_BYTES:
-		typeStr = "[]bytes"
+		typeStr = "[]byte"
 	case irt.Primitive_LEGACYFLOAT64:
 		typeStr = "float64"
 		if isPtr {
@@ -496,8 +727,8 @@ func primType(typ *irt.Primitive, isPtr bool, refs refMaker) string {
 			typeStr = "*"+typeStr
 		}
 	default:
-		// TODO: tracking of errors
-		respond.GeneralError(nil, "unknown primitive type", "type", typ.Type)
+		resp := refs.responder()
+		resp.ErrorAt(resp.Node(typ), nil, "unknown primitive type", "type", typ.Type)
 		typeStr = "<invalid>"
 	}
 	return typeStr
@@ -505,6 +736,7 @@ func primType(typ *irt.Primitive, isPtr bool, refs refMaker) string {
 
 type refMaker interface {
 	MakeGVRef(*irt.Reference) string
+	responder() *respond.Responder
 }
 
 func listType(list *irt.List, refs refMaker) string {
@@ -581,6 +813,55 @@ func listMapType(listMap *irt.ListMap, fieldTag *tags, comment *comment, refs re
 	return "[]"+refs.MakeGVRef(listMap.Items)
 }
 
+// unionVariants returns the variants of a union as they appear in Go.
+// Only one is set at a time, so they're all optional (and thus pointers,
+// unless nil already means absent), whatever the KDL says.  The copies
+// share their types & defaults with the originals, so logs about those
+// still point at the KDL.
+func unionVariants(union *irt.Union) []*irt.Field {
+	variants := make([]*irt.Field, len(union.Variants))
+	for i, field := range union.Variants {
+		variant := proto.Clone(field).(*irt.Field)
+		variant.Type = field.Type
+		variant.Default = field.Default
+		variant.Optional = true
+		variant.ZeroMeansAbsent = false
+		variants[i] = variant
+	}
+	return variants
+}
+
+// isPointerField checks if the given field is represented as a pointer,
+// so that we can tell when it's absent.  Per the API conventions, that's
+// optional fields whose zero value is valid, except for embedded fields and
+// anything that's eventually a slice or a map (nil already means absent).
+func isPointerField(types *typegraph.Graph, field *irt.Field) bool {
+	if !field.Optional || field.ZeroMeansAbsent || field.Embedded {
+		return false
+	}
+	switch typ := field.Type.(type) {
+	case *irt.Field_Primitive:
+		return typ.Primitive.Type != irt.Primitive_BYTES
+	case *irt.Field_NamedType:
+		term, known := types.Resolve(typ.NamedType)
+		if !known {
+			// not much we can tell about it, so err on the side of being
+			// able to tell when it's absent
+			return true
+		}
+		wrapper, isWrapper := term.(typegraph.TerminalWrapper)
+		if !isWrapper {
+			// structs, unions, enums & kinds
+			return true
+		}
+		alias, isPrim := wrapper.Wrapper.Type.(*irt.Subtype_PrimitiveAlias)
+		return isPrim && alias.PrimitiveAlias.Type != irt.Primitive_BYTES
+	default:
+		// sets, lists, & maps
+		return false
+	}
+}
+
 func writeField(field *irt.Field, out *subWriter) {
 	comment := comment{doc: field.Docs}
 	fieldTag := tags{
@@ -602,19 +883,27 @@ func writeField(field *irt.Field, out *subWriter) {
 	optOrRep := "" // either proto opt or proto rep guess
 	protoType := "bytes" // always gonna either be bytes or varint, never anything else
 
-	if field.Optional {
+	switch {
+	case field.Embedded:
+		// embedded fields are flattened into their parent, so there's
+		// nothing to omit
+		fieldTag.json = append(fieldTag.json, "inline")
+	case field.Optional:
 		fieldTag.json = append(fieldTag.json, "omitempty")
 		comment.markers = append(comment.markers, marker{name: "optional"})
 		optOrRep = "opt"
 	}
-	if field.Embedded {
-		fieldTag.json = append(fieldTag.json, "inline")
-	}
 
-	// TODO(directxman12): default
-	// TODO(directxman12): constraints
+	if field.Default != nil {
+		// the actual defaulting happens in SetDefaults_<Type> (see
+		// writeDefaults), this is just so folks can see what it is
+		if val, err := json.Marshal(field.Default.AsInterface()); err == nil {
+			comment.markers = append(comment.markers, marker{name: "default", value: string(val)})
+		}
+	}
+	// constraints are enforced by Validate<Type> (see writeValidation)
 
-	isPtr := !field.ZeroMeansAbsent && field.Optional
+	isPtr := isPointerField(out.parent.Types, field)
 	typeStr := ""
 	switch typ := field.Type.(type) {
 	case *irt.Field_Primitive:
@@ -625,7 +914,6 @@ func writeField(field *irt.Field, out *subWriter) {
 		typeStr = primType(typ.Primitive, isPtr, out)
 	case *irt.Field_NamedType:
 		typeStr = out.MakeGVRef(typ.NamedType)
-		// TODO: we need to check the eventual type of this reference to know this
 		if isPtr {
 			typeStr = "*"+typeStr
 		}
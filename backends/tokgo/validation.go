@@ -0,0 +1,522 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package tokgo
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	ir "k8s.io/idl/ckdl-ir/goir"
+	irc "k8s.io/idl/ckdl-ir/goir/constraints"
+	irt "k8s.io/idl/ckdl-ir/goir/types"
+)
+
+// NB(directxman12): the goal here is to reject the same things that the
+// CRD schema would, so that built-in-style API servers & webhooks don't
+// drift from CRDs.  CEL rules aren't enforced here -- that'd need a CEL
+// runtime in every consumer.
+//
+// The constraints in the IR can't distinguish "unset" from zero, so we treat
+// zero bounds as unset.
+
+type validationWriter struct {
+	*pkgWriter
+	index *typeIndex
+
+	// patterns maps regular expressions to the variables holding their
+	// compiled forms
+	patterns     map[string]string
+	patternNames []string
+}
+
+func (w *validationWriter) field() string {
+	return w.Import("k8s.io/apimachinery/pkg/util/validation/field", "field")
+}
+
+// appendErr writes a statement that records the given error.
+func (w *validationWriter) appendErr(dst *bytes.Buffer, format string, args ...interface{}) {
+	fmt.Fprintf(dst, "allErrs = append(allErrs, %s.%s)\n", w.field(), fmt.Sprintf(format, args...))
+}
+
+// pattern returns the variable holding the compiled version of the given
+// regular expression.
+func (w *validationWriter) pattern(expr string) string {
+	if name, exists := w.patterns[expr]; exists {
+		return name
+	}
+	name := fmt.Sprintf("validationPattern%d", len(w.patterns)+1)
+	w.patterns[expr] = name
+	w.patternNames = append(w.patternNames, expr)
+	return name
+}
+
+func (w *validationWriter) numericChecks(c *irc.Numeric, isFloat bool, expr, path string, dst *bytes.Buffer) {
+	if c == nil {
+		return
+	}
+	if c.Minimum != 0 {
+		op, desc := "<", "greater than or equal to"
+		if c.ExclusiveMinimum {
+			op, desc = "<=", "greater than"
+		}
+		fmt.Fprintf(dst, "if %s %s %d {\n", expr, op, c.Minimum)
+		w.appendErr(dst, "Invalid(%s, %s, %q)", path, expr, fmt.Sprintf("must be %s %d", desc, c.Minimum))
+		fmt.Fprintln(dst, "}")
+	}
+	if c.Maximum != 0 {
+		op, desc := ">", "less than or equal to"
+		if c.ExclusiveMaximum {
+			op, desc = ">=", "less than"
+		}
+		fmt.Fprintf(dst, "if %s %s %d {\n", expr, op, c.Maximum)
+		w.appendErr(dst, "Invalid(%s, %s, %q)", path, expr, fmt.Sprintf("must be %s %d", desc, c.Maximum))
+		fmt.Fprintln(dst, "}")
+	}
+	if c.MultipleOf != 0 {
+		if isFloat {
+			fmt.Fprintf(dst, "if %s.Mod(float64(%s), %d) != 0 {\n", w.Import("math", "math"), expr, c.MultipleOf)
+		} else {
+			fmt.Fprintf(dst, "if %s%%%d != 0 {\n", expr, c.MultipleOf)
+		}
+		w.appendErr(dst, "Invalid(%s, %s, %q)", path, expr, fmt.Sprintf("must be a multiple of %d", c.MultipleOf))
+		fmt.Fprintln(dst, "}")
+	}
+}
+
+func (w *validationWriter) stringChecks(c *irc.String, expr, path string, dst *bytes.Buffer) {
+	if c == nil {
+		return
+	}
+	// lengths in the schema are in characters, not bytes
+	if c.MaxLength != 0 {
+		fmt.Fprintf(dst, "if %s.RuneCountInString(string(%s)) > %d {\n", w.Import("unicode/utf8", "utf8"), expr, c.MaxLength)
+		w.appendErr(dst, "TooLong(%s, %s, %d)", path, expr, c.MaxLength)
+		fmt.Fprintln(dst, "}")
+	}
+	if c.MinLength != 0 {
+		fmt.Fprintf(dst, "if %s.RuneCountInString(string(%s)) < %d {\n", w.Import("unicode/utf8", "utf8"), expr, c.MinLength)
+		w.appendErr(dst, "Invalid(%s, %s, %q)", path, expr, fmt.Sprintf("must be at least %d characters", c.MinLength))
+		fmt.Fprintln(dst, "}")
+	}
+	if c.Pattern != "" {
+		fmt.Fprintf(dst, "if !%s.MatchString(string(%s)) {\n", w.pattern(c.Pattern), expr)
+		w.appendErr(dst, "Invalid(%s, %s, %q)", path, expr, "must match the regular expression "+goString(c.Pattern))
+		fmt.Fprintln(dst, "}")
+	}
+}
+
+// listChecks writes checks for list constraints.  items is the shape of
+// the list's items, if known.
+func (w *validationWriter) listChecks(c *irc.List, items *goShape, expr, path string, dst *bytes.Buffer) {
+	if c == nil {
+		return
+	}
+	if c.MaxItems != 0 {
+		fmt.Fprintf(dst, "if len(%s) > %d {\n", expr, c.MaxItems)
+		w.appendErr(dst, "TooMany(%s, len(%s), %d)", path, expr, c.MaxItems)
+		fmt.Fprintln(dst, "}")
+	}
+	if c.MinItems != 0 {
+		fmt.Fprintf(dst, "if len(%s) < %d {\n", expr, c.MinItems)
+		w.appendErr(dst, "Invalid(%s, len(%s), %q)", path, expr, fmt.Sprintf("must have at least %d items", c.MinItems))
+		fmt.Fprintln(dst, "}")
+	}
+	if c.UniqueItems {
+		w.uniqueChecks(items, expr, path, dst)
+	}
+}
+
+// uniqueChecks writes a check that each item in the given list is distinct.
+func (w *validationWriter) uniqueChecks(items *goShape, expr, path string, dst *bytes.Buffer) {
+	if items != nil && items.kind == copyShallow {
+		fmt.Fprintf(dst, "{\nseen := make(map[%s]struct{}, len(%s))\n", items.typeStr, expr)
+		fmt.Fprintf(dst, "for i, item := range %s {\n", expr)
+		fmt.Fprintln(dst, "if _, dup := seen[item]; dup {")
+		w.appendErr(dst, "Duplicate(%s.Index(i), item)", path)
+		fmt.Fprintln(dst, "}\nseen[item] = struct{}{}\n}\n}")
+		return
+	}
+	// not comparable, so fall back to the slow way
+	fmt.Fprintf(dst, "for i := range %s {\n", expr)
+	fmt.Fprintln(dst, "for j := 0; j < i; j++ {")
+	fmt.Fprintf(dst, "if %s.DeepEqual(%[2]s[i], %[2]s[j]) {\n", w.Import("reflect", "reflect"), expr)
+	w.appendErr(dst, "Duplicate(%s.Index(i), %s[i])", path, expr)
+	fmt.Fprintln(dst, "break\n}\n}\n}")
+}
+
+func (w *validationWriter) objectChecks(c *irc.Object, expr, path string, dst *bytes.Buffer) {
+	if c == nil {
+		return
+	}
+	if c.MaxProperties != 0 {
+		fmt.Fprintf(dst, "if len(%s) > %d {\n", expr, c.MaxProperties)
+		w.appendErr(dst, "TooMany(%s, len(%s), %d)", path, expr, c.MaxProperties)
+		fmt.Fprintln(dst, "}")
+	}
+	if c.MinProperties != 0 {
+		fmt.Fprintf(dst, "if len(%s) < %d {\n", expr, c.MinProperties)
+		w.appendErr(dst, "Invalid(%s, len(%s), %q)", path, expr, fmt.Sprintf("must have at least %d entries", c.MinProperties))
+		fmt.Fprintln(dst, "}")
+	}
+}
+
+func (w *validationWriter) primChecks(prim *irt.Primitive, expr, path string, dst *bytes.Buffer) {
+	switch prim.Type {
+	case irt.Primitive_LEGACYINT32, irt.Primitive_INT64:
+		w.numericChecks(prim.GetNumericConstraints(), false, expr, path, dst)
+	case irt.Primitive_LEGACYFLOAT64:
+		w.numericChecks(prim.GetNumericConstraints(), true, expr, path, dst)
+	case irt.Primitive_STRING:
+		w.stringChecks(prim.GetStringConstraints(), expr, path, dst)
+	default:
+		// the string constraints on times, quantities, etc apply to
+		// their serialized forms, which we don't have here
+	}
+}
+
+// refChecks writes checks for the constraints attached to a reference, then
+// validates the referenced type itself.  expr must be addressable.
+func (w *validationWriter) refChecks(ref *irt.Reference, expr, path string, dst *bytes.Buffer) {
+	if c := ref.Constraints; c != nil {
+		switch {
+		case c.GetNum() != nil:
+			prim, _ := w.index.scalarRef(ref)
+			w.numericChecks(c.GetNum(), prim == irt.Primitive_LEGACYFLOAT64, expr, path, dst)
+		case c.GetStr() != nil:
+			w.stringChecks(c.GetStr(), expr, path, dst)
+		case c.GetList() != nil:
+			w.listChecks(c.GetList(), nil, expr, path, dst)
+		case c.GetObj() != nil:
+			w.objectChecks(c.GetObj(), expr, path, dst)
+		}
+	}
+
+	if !w.index.Known(ref) {
+		// e.g. the types we synthesize for primitives
+		return
+	}
+	fmt.Fprintf(dst, "allErrs = append(allErrs, %s(%s, %s)...)\n", funcForRef(w, ref, "Validate"), addrOf(expr), path)
+}
+
+// addrOf takes the address of the given expression, avoiding `&*ptr`.
+func addrOf(expr string) string {
+	if strings.HasPrefix(expr, "*") {
+		return expr[1:]
+	}
+	return "&" + expr
+}
+
+// eachItem writes a loop that validates each item of the given list, if
+// there's anything to validate.
+func (w *validationWriter) eachItem(expr, path string, dst *bytes.Buffer, body func(item, itemPath string, dst *bytes.Buffer)) {
+	inner := new(bytes.Buffer)
+	body(expr+"[i]", path+".Index(i)", inner)
+	if inner.Len() == 0 {
+		return
+	}
+	fmt.Fprintf(dst, "for i := range %s {\n", expr)
+	inner.WriteTo(dst)
+	fmt.Fprintln(dst, "}")
+}
+
+func (w *validationWriter) listItems(list *irt.List, expr, path string, dst *bytes.Buffer) {
+	w.eachItem(expr, path, dst, func(item, itemPath string, dst *bytes.Buffer) {
+		switch items := list.Items.(type) {
+		case *irt.List_Primitive:
+			w.primChecks(items.Primitive, item, itemPath, dst)
+		case *irt.List_Reference:
+			w.refChecks(items.Reference, item, itemPath, dst)
+		}
+	})
+}
+
+func (w *validationWriter) listTypeChecks(list *irt.List, expr, path string, dst *bytes.Buffer) {
+	w.listChecks(list.ListConstraints, w.index.listShape(list, w).elem, expr, path, dst)
+	w.listItems(list, expr, path, dst)
+}
+
+func (w *validationWriter) setTypeChecks(set *irt.Set, expr, path string, dst *bytes.Buffer) {
+	items := w.index.setShape(set, w).elem
+	w.listChecks(set.ListConstraints, items, expr, path, dst)
+	if !set.GetListConstraints().GetUniqueItems() {
+		// sets are always unique
+		w.uniqueChecks(items, expr, path, dst)
+	}
+	w.eachItem(expr, path, dst, func(item, itemPath string, dst *bytes.Buffer) {
+		switch items := set.Items.(type) {
+		case *irt.Set_Primitive:
+			w.primChecks(items.Primitive, item, itemPath, dst)
+		case *irt.Set_Reference:
+			w.refChecks(items.Reference, item, itemPath, dst)
+		}
+	})
+}
+
+func (w *validationWriter) mapTypeChecks(primMap *irt.PrimitiveMap, expr, path string, dst *bytes.Buffer) {
+	w.objectChecks(primMap.ObjectConstraints, expr, path, dst)
+
+	// map values aren't addressable, so validate a copy
+	inner := new(bytes.Buffer)
+	valPath := path + ".Key(string(key))"
+	switch val := primMap.Value.(type) {
+	case *irt.PrimitiveMap_PrimitiveValue:
+		w.primChecks(val.PrimitiveValue, "val", valPath, inner)
+	case *irt.PrimitiveMap_ReferenceValue:
+		w.refChecks(val.ReferenceValue, "val", valPath, inner)
+	case *irt.PrimitiveMap_SimpleListValue:
+		w.listTypeChecks(val.SimpleListValue, "val", valPath, inner)
+	}
+	if inner.Len() == 0 {
+		return
+	}
+	fmt.Fprintf(dst, "for key, val := range %s {\n", expr)
+	inner.WriteTo(dst)
+	fmt.Fprintln(dst, "}")
+}
+
+func (w *validationWriter) listMapTypeChecks(listMap *irt.ListMap, expr, path string, dst *bytes.Buffer) {
+	w.listChecks(listMap.ListConstraints, nil, expr, path, dst)
+	w.listMapKeyChecks(listMap, expr, path, dst)
+	w.eachItem(expr, path, dst, func(item, itemPath string, dst *bytes.Buffer) {
+		w.refChecks(listMap.Items, item, itemPath, dst)
+	})
+}
+
+// listMapKeyChecks writes a check that the keys of each item in a list-map
+// are unique.
+func (w *validationWriter) listMapKeyChecks(listMap *irt.ListMap, expr, path string, dst *bytes.Buffer) {
+	itemType, known := w.index.Subtypes[keyFor(listMap.Items)]
+	if !known || itemType.GetStruct() == nil {
+		// already complained about by the compiler
+		return
+	}
+	var keyParts []string
+	var nilChecks []string
+	for _, keyName := range listMap.KeyField {
+		for _, field := range itemType.GetStruct().Fields {
+			if field.Name != keyName {
+				continue
+			}
+			shape := w.index.fieldShape(field, w)
+			part := "item." + goFieldName(field, shape)
+			if shape.kind == copyPtr {
+				// unset (probably to-be-defaulted) keys can't conflict yet
+				nilChecks = append(nilChecks, part+" == nil")
+				part = "*" + part
+			}
+			keyParts = append(keyParts, part)
+		}
+	}
+	if len(keyParts) == 0 {
+		return
+	}
+
+	key := keyParts[0]
+	if len(keyParts) > 1 {
+		key = fmt.Sprintf("[%d]interface{}{%s}", len(keyParts), strings.Join(keyParts, ", "))
+	}
+	fmt.Fprintf(dst, "{\nseen := make(map[interface{}]struct{}, len(%s))\n", expr)
+	fmt.Fprintf(dst, "for i := range %s {\n", expr)
+	fmt.Fprintf(dst, "item := &%s[i]\n", expr)
+	if len(nilChecks) > 0 {
+		fmt.Fprintf(dst, "if %s {\ncontinue\n}\n", strings.Join(nilChecks, " || "))
+	}
+	fmt.Fprintf(dst, "key := %s\n", key)
+	fmt.Fprintln(dst, "if _, dup := seen[key]; dup {")
+	w.appendErr(dst, "Duplicate(%s.Index(i), key)", path)
+	fmt.Fprintln(dst, "}\nseen[key] = struct{}{}\n}\n}")
+}
+
+// fieldTypeChecks writes checks for the value of a field, which must be
+// addressable.
+func (w *validationWriter) fieldTypeChecks(field *irt.Field, expr, path string, dst *bytes.Buffer) {
+	switch typ := field.Type.(type) {
+	case *irt.Field_Primitive:
+		w.primChecks(typ.Primitive, expr, path, dst)
+	case *irt.Field_NamedType:
+		w.refChecks(typ.NamedType, expr, path, dst)
+	case *irt.Field_Set:
+		w.setTypeChecks(typ.Set, expr, path, dst)
+	case *irt.Field_List:
+		w.listTypeChecks(typ.List, expr, path, dst)
+	case *irt.Field_PrimitiveMap:
+		w.mapTypeChecks(typ.PrimitiveMap, expr, path, dst)
+	case *irt.Field_ListMap:
+		w.listMapTypeChecks(typ.ListMap, expr, path, dst)
+	default:
+		panic(fmt.Sprintf("unreachable: unknown field type %T", typ))
+	}
+}
+
+// isSet returns an expression checking if the given value was set, as best
+// we can tell.
+func (w *validationWriter) isSet(shape *goShape, expr string) string {
+	switch {
+	case shape.kind == copyPtr, shape.kind == copySlice, shape.kind == copyMap, shape.nilable:
+		return expr + " != nil"
+	default:
+		return fmt.Sprintf("!%s.ValueOf(%s).IsZero()", w.Import("reflect", "reflect"), expr)
+	}
+}
+
+// fieldChecks writes checks for a field's value.
+func (w *validationWriter) fieldChecks(field *irt.Field, inUnion bool, dst *bytes.Buffer) {
+	shape := w.index.fieldShape(field, w)
+	expr := "obj." + goFieldName(field, shape)
+	path := fmt.Sprintf("fldPath.Child(%q)", field.Name)
+	if field.Embedded {
+		path = "fldPath"
+	}
+
+	inner := new(bytes.Buffer)
+	if shape.kind == copyPtr {
+		w.fieldTypeChecks(field, "*"+expr, path, inner)
+		if inner.Len() > 0 {
+			fmt.Fprintf(dst, "if %s != nil {\n", expr)
+			inner.WriteTo(dst)
+			fmt.Fprintln(dst, "}")
+		}
+		return
+	}
+	w.fieldTypeChecks(field, expr, path, inner)
+	if inUnion && inner.Len() > 0 {
+		// only the chosen variant needs to be valid
+		fmt.Fprintf(dst, "if %s {\n", w.isSet(shape, expr))
+		inner.WriteTo(dst)
+		fmt.Fprintln(dst, "}")
+		return
+	}
+
+	// required fields aren't pointers or omitempty, so they're always
+	// present when serialized, which is all the CRD schema's required
+	// list checks for (union variants are checked separately)
+	inner.WriteTo(dst)
+}
+
+// unionChecks writes checks that exactly one variant of a union is set, and
+// that the tag (if any) matches it.
+func (w *validationWriter) unionChecks(union *irt.Union, dst *bytes.Buffer) {
+	names := make([]string, len(union.Variants))
+	fmt.Fprintln(dst, "{\nvar set []string")
+	for i, field := range unionVariants(union) {
+		shape := w.index.fieldShape(field, w)
+		names[i] = "`" + field.Name + "`"
+		fmt.Fprintf(dst, "if %s {\n", w.isSet(shape, "obj."+goFieldName(field, shape)))
+		fmt.Fprintf(dst, "set = append(set, %q)\n", field.Name)
+		fmt.Fprintln(dst, "}")
+	}
+	detail := fmt.Sprintf("exactly one of %s must be set", strings.Join(names, ", "))
+	fmt.Fprintln(dst, "switch len(set) {")
+	fmt.Fprintln(dst, "case 0:")
+	w.appendErr(dst, "Required(fldPath, %q)", detail)
+	fmt.Fprintln(dst, "case 1:")
+	if !union.Untagged {
+		fmt.Fprintf(dst, "if string(obj.%s) != set[0] {\n", strings.Title(union.Tag))
+		w.appendErr(dst, "NotSupported(fldPath.Child(%q), obj.%s, set)", union.Tag, strings.Title(union.Tag))
+		fmt.Fprintln(dst, "}")
+	}
+	fmt.Fprintln(dst, "default:")
+	w.appendErr(dst, "Invalid(fldPath, set, %q)", detail)
+	fmt.Fprintln(dst, "}\n}")
+}
+
+// validateFunc writes a Validate function for the given type, with the
+// given body.
+func (w *validationWriter) validateFunc(typeName string, body func(dst *bytes.Buffer)) {
+	field := w.field()
+	out := new(bytes.Buffer)
+	fmt.Fprintf(out, "// Validate%[1]s checks the given %[1]s against the constraints from its definition, returning any problems found.\n", typeName)
+	fmt.Fprintf(out, "func Validate%[1]s(obj *%[1]s, fldPath *%[2]s.Path) %[2]s.ErrorList {\n", typeName, field)
+	fmt.Fprintf(out, "allErrs := %s.ErrorList{}\n", field)
+	body(out)
+	fmt.Fprintln(out, "return allErrs\n}")
+	w.Code(typeName, out)
+}
+
+func (w *validationWriter) structValidation(typeName string, fields []*irt.Field) {
+	w.validateFunc(typeName, func(dst *bytes.Buffer) {
+		for _, field := range fields {
+			w.fieldChecks(field, false, dst)
+		}
+	})
+}
+
+// writeValidation writes Validate functions for all the types in the given
+// group-version, matching what writeGo generates.
+func writeValidation(inputs []*ir.GroupVersion, out *validationWriter) {
+	// we compute Go types for fields that we don't end up validating
+	out.PruneImports = true
+	fmt.Fprint(out.header, "// Code generated by ckdl-to-tokgo. DO NOT EDIT.\n\n")
+	out.Package(comment{})
+
+	for _, gv := range inputs {
+		for _, kind := range gv.Kinds {
+			out.structValidation(nameType(kind.Name, kind.Attributes), kind.Fields)
+		}
+		for _, subtype := range gv.Types {
+			typeName := nameType(subtype.Name, subtype.Attributes)
+			switch body := subtype.Type.(type) {
+			case *irt.Subtype_Struct:
+				out.structValidation(typeName, body.Struct.Fields)
+			case *irt.Subtype_Union:
+				out.validateFunc(typeName, func(dst *bytes.Buffer) {
+					for _, field := range unionVariants(body.Union) {
+						out.fieldChecks(field, true, dst)
+					}
+					out.unionChecks(body.Union, dst)
+				})
+			case *irt.Subtype_Enum:
+				out.validateFunc(typeName, func(dst *bytes.Buffer) {
+					consts := make([]string, len(body.Enum.Variants))
+					values := make([]string, len(body.Enum.Variants))
+					for i, variant := range body.Enum.Variants {
+						consts[i] = typeName + variant.Name
+						values[i] = fmt.Sprintf("%q", variant.Name)
+					}
+					fmt.Fprintf(dst, "switch *obj {\ncase %s:\ndefault:\n", strings.Join(consts, ", "))
+					out.appendErr(dst, "NotSupported(fldPath, *obj, []string{%s})", strings.Join(values, ", "))
+					fmt.Fprintln(dst, "}")
+				})
+			case *irt.Subtype_PrimitiveAlias:
+				out.validateFunc(typeName, func(dst *bytes.Buffer) {
+					out.primChecks(body.PrimitiveAlias, "*obj", "fldPath", dst)
+				})
+			case *irt.Subtype_ReferenceAlias:
+				out.validateFunc(typeName, func(dst *bytes.Buffer) {
+					ref := body.ReferenceAlias
+					out.refChecks(ref, fmt.Sprintf("*(*%s)(obj)", out.MakeGVRef(ref)), "fldPath", dst)
+				})
+			case *irt.Subtype_Set:
+				out.validateFunc(typeName, func(dst *bytes.Buffer) {
+					out.setTypeChecks(body.Set, "(*obj)", "fldPath", dst)
+				})
+			case *irt.Subtype_List:
+				out.validateFunc(typeName, func(dst *bytes.Buffer) {
+					out.listTypeChecks(body.List, "(*obj)", "fldPath", dst)
+				})
+			case *irt.Subtype_PrimitiveMap:
+				out.validateFunc(typeName, func(dst *bytes.Buffer) {
+					out.mapTypeChecks(body.PrimitiveMap, "(*obj)", "fldPath", dst)
+				})
+			case *irt.Subtype_ListMap:
+				out.validateFunc(typeName, func(dst *bytes.Buffer) {
+					out.listMapTypeChecks(body.ListMap, "(*obj)", "fldPath", dst)
+				})
+			default:
+				panic(fmt.Sprintf("unreachable: unknown subtype type %T", body))
+			}
+		}
+	}
+
+	if len(out.patternNames) > 0 {
+		regexp := out.Import("regexp", "regexp")
+		vars := new(bytes.Buffer)
+		fmt.Fprintln(vars, "var (")
+		for _, expr := range out.patternNames {
+			fmt.Fprintf(vars, "%s = %s.MustCompile(%s)\n", out.patterns[expr], regexp, goString(expr))
+		}
+		fmt.Fprintln(vars, ")")
+		out.Code("validationPatterns", vars)
+	}
+}
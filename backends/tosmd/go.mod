@@ -0,0 +1,10 @@
+module k8s.io/idl/backends/tosmd
+
+go 1.15
+
+replace (
+	k8s.io/idl/backends/common => ../common
+	k8s.io/idl/ckdl-ir/goir => ../../ckdl-ir/goir
+)
+
+require k8s.io/idl/backends/common v0.0.0-00010101000000-000000000000
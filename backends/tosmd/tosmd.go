@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+
+// Package tosmd is a backend that writes the structured-merge-diff schema for the given
+// kinds (or every kind in the bundle, if none are given), so that API
+// servers and tests can build server-side apply type converters without
+// going through OpenAPI.
+//
+// Types are named the way the API server names CRD definitions, with the
+// group reversed (e.g. widgets.example.com/v1::Widget becomes
+// com.example.widgets.v1.Widget).  The schema is written to smd-schema.yaml,
+// or to the file given with the output-file option.
+package tosmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/idl/backends/common/backend"
+	"k8s.io/idl/backends/common/request"
+	"k8s.io/idl/backends/common/respond"
+	"k8s.io/idl/backends/common/smd"
+	"k8s.io/idl/backends/common/typegraph"
+)
+
+// schemaName names a type after its reversed group, version, and name.
+func schemaName(name typegraph.Name) string {
+	group := "io.k8s.api.core"
+	if name.Group != "" {
+		parts := strings.Split(name.Group, ".")
+		for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+			parts[i], parts[j] = parts[j], parts[i]
+		}
+		group = strings.Join(parts, ".")
+	}
+	return group + "." + name.Version + "." + name.FullName
+}
+
+// Backend is the structured-merge-diff schema backend.
+var Backend backend.Backend = backend.Func(generate)
+
+func generate(_ context.Context, req *request.Request, resp *respond.Responder) error {
+	loader, types := req.Loader, req.Types
+	resp.Debug("beginning")
+	if err := req.Options.CheckKnown("output-file"); err != nil {
+		return err
+	}
+	outFile := req.Options.String("output-file", "smd-schema.yaml")
+
+	graph := typegraph.New(loader.GroupVersions())
+	var names []typegraph.Name
+	for _, typ := range types {
+		names = append(names, typegraph.Name{
+			GroupVersion: request.GroupVersion{Group: typ.Group, Version: typ.Version},
+			FullName:     typ.Type,
+		})
+	}
+	if len(names) == 0 {
+		for name := range graph.Kinds {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool { return names[i].String() < names[j].String() })
+	}
+
+	builder := smd.NewBuilder(graph, schemaName)
+	hadErr := false
+	for _, name := range names {
+		added, err := builder.Add(name)
+		if err != nil {
+			resp.GeneralError(err, "unable to build schema", "type", name.String())
+			hadErr = true
+			continue
+		}
+		resp.Debug("added type", "type", name.String(), "name", added)
+	}
+	if hadErr {
+		return backend.ErrReported
+	}
+
+	schemaYAML, err := builder.YAML()
+	if err != nil {
+		return fmt.Errorf("unable to serialize schema: %w", err)
+	}
+	resp.File(outFile, schemaYAML)
+	return nil
+}
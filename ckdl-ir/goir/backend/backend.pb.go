@@ -10,6 +10,8 @@ import (
 	proto "github.com/golang/protobuf/proto"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	goir "k8s.io/idl/ckdl-ir/goir"
+	types "k8s.io/idl/ckdl-ir/goir/types"
 	reflect "reflect"
 	sync "sync"
 )
@@ -25,11 +27,18 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+// Levels are numbered in the order they were added, not by severity.
 type Log_Level int32
 
 const (
 	Log_INFO  Log_Level = 0
 	Log_ERROR Log_Level = 1
+	// WARNING is for problems that don't stop the backend from
+	// producing correct output.
+	Log_WARNING Log_Level = 2
+	// DEBUG is for details that are only interesting when something's
+	// gone wrong.  kdlc only shows them when asked to.
+	Log_DEBUG Log_Level = 3
 )
 
 // Enum value maps for Log_Level.
@@ -37,10 +46,14 @@ var (
 	Log_Level_name = map[int32]string{
 		0: "INFO",
 		1: "ERROR",
+		2: "WARNING",
+		3: "DEBUG",
 	}
 	Log_Level_value = map[string]int32{
-		"INFO":  0,
-		"ERROR": 1,
+		"INFO":    0,
+		"ERROR":   1,
+		"WARNING": 2,
+		"DEBUG":   3,
 	}
 )
 
@@ -68,9 +81,212 @@ func (x Log_Level) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Log_Level.Descriptor instead.
 func (Log_Level) EnumDescriptor() ([]byte, []int) {
-	return file_backend_proto_rawDescGZIP(), []int{2, 0}
+	return file_backend_proto_rawDescGZIP(), []int{4, 0}
 }
 
+// Request is what the compiler sends a backend on stdin.
+type Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// bundle contains the compiled root files, plus everything they depend on.
+	Bundle *goir.Bundle `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// roots are the virtual paths of the files the compiler was asked to
+	// compile, as opposed to the ones it pulled in as dependencies.
+	Roots []string `protobuf:"bytes,2,rep,name=roots,proto3" json:"roots,omitempty"`
+	// types are the specific types to generate for.  Empty means everything.
+	Types []*types.Reference `protobuf:"bytes,3,rep,name=types,proto3" json:"types,omitempty"`
+	// parameters are the backend-specific options, in the order they were
+	// given.  Parameters may be repeated.
+	Parameters []*Parameter `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	// output_dir is where the compiler will write files the backend
+	// produces.  Backends shouldn't write there themselves, but may use it
+	// to figure out what's already there.
+	OutputDir string `protobuf:"bytes,5,opt,name=output_dir,json=outputDir,proto3" json:"output_dir,omitempty"`
+	// compiler_version is the version of the compiler that produced the
+	// bundle.
+	CompilerVersion string `protobuf:"bytes,6,opt,name=compiler_version,json=compilerVersion,proto3" json:"compiler_version,omitempty"`
+}
+
+func (x *Request) Reset() {
+	*x = Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request) ProtoMessage() {}
+
+func (x *Request) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request.ProtoReflect.Descriptor instead.
+func (*Request) Descriptor() ([]byte, []int) {
+	return file_backend_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Request) GetBundle() *goir.Bundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+func (x *Request) GetRoots() []string {
+	if x != nil {
+		return x.Roots
+	}
+	return nil
+}
+
+func (x *Request) GetTypes() []*types.Reference {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *Request) GetParameters() []*Parameter {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *Request) GetOutputDir() string {
+	if x != nil {
+		return x.OutputDir
+	}
+	return ""
+}
+
+func (x *Request) GetCompilerVersion() string {
+	if x != nil {
+		return x.CompilerVersion
+	}
+	return ""
+}
+
+// Parameter is a single backend option.  The compiler picks the type
+// based on the value it was given (so `-f x=true` is a bool).
+type Parameter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Types that are assignable to Value:
+	//	*Parameter_Str
+	//	*Parameter_Bool
+	//	*Parameter_Int
+	Value isParameter_Value `protobuf_oneof:"value"`
+}
+
+func (x *Parameter) Reset() {
+	*x = Parameter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backend_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Parameter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Parameter) ProtoMessage() {}
+
+func (x *Parameter) ProtoReflect() protoreflect.Message {
+	mi := &file_backend_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Parameter.ProtoReflect.Descriptor instead.
+func (*Parameter) Descriptor() ([]byte, []int) {
+	return file_backend_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Parameter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (m *Parameter) GetValue() isParameter_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *Parameter) GetStr() string {
+	if x, ok := x.GetValue().(*Parameter_Str); ok {
+		return x.Str
+	}
+	return ""
+}
+
+func (x *Parameter) GetBool() bool {
+	if x, ok := x.GetValue().(*Parameter_Bool); ok {
+		return x.Bool
+	}
+	return false
+}
+
+func (x *Parameter) GetInt() int64 {
+	if x, ok := x.GetValue().(*Parameter_Int); ok {
+		return x.Int
+	}
+	return 0
+}
+
+type isParameter_Value interface {
+	isParameter_Value()
+}
+
+type Parameter_Str struct {
+	Str string `protobuf:"bytes,2,opt,name=str,proto3,oneof"`
+}
+
+type Parameter_Bool struct {
+	Bool bool `protobuf:"varint,3,opt,name=bool,proto3,oneof"`
+}
+
+type Parameter_Int struct {
+	Int int64 `protobuf:"varint,4,opt,name=int,proto3,oneof"`
+}
+
+func (*Parameter_Str) isParameter_Value() {}
+
+func (*Parameter_Bool) isParameter_Value() {}
+
+func (*Parameter_Int) isParameter_Value() {}
+
 type Response struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -85,7 +301,7 @@ type Response struct {
 func (x *Response) Reset() {
 	*x = Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_backend_proto_msgTypes[0]
+		mi := &file_backend_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -98,7 +314,7 @@ func (x *Response) String() string {
 func (*Response) ProtoMessage() {}
 
 func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_msgTypes[0]
+	mi := &file_backend_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -111,7 +327,7 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response.ProtoReflect.Descriptor instead.
 func (*Response) Descriptor() ([]byte, []int) {
-	return file_backend_proto_rawDescGZIP(), []int{0}
+	return file_backend_proto_rawDescGZIP(), []int{2}
 }
 
 func (m *Response) GetType() isResponse_Type {
@@ -163,7 +379,7 @@ type File struct {
 func (x *File) Reset() {
 	*x = File{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_backend_proto_msgTypes[1]
+		mi := &file_backend_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -176,7 +392,7 @@ func (x *File) String() string {
 func (*File) ProtoMessage() {}
 
 func (x *File) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_msgTypes[1]
+	mi := &file_backend_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -189,7 +405,7 @@ func (x *File) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use File.ProtoReflect.Descriptor instead.
 func (*File) Descriptor() ([]byte, []int) {
-	return file_backend_proto_rawDescGZIP(), []int{1}
+	return file_backend_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *File) GetName() string {
@@ -218,7 +434,7 @@ type Log struct {
 func (x *Log) Reset() {
 	*x = Log{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_backend_proto_msgTypes[2]
+		mi := &file_backend_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -231,7 +447,7 @@ func (x *Log) String() string {
 func (*Log) ProtoMessage() {}
 
 func (x *Log) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_msgTypes[2]
+	mi := &file_backend_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -244,7 +460,7 @@ func (x *Log) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Log.ProtoReflect.Descriptor instead.
 func (*Log) Descriptor() ([]byte, []int) {
-	return file_backend_proto_rawDescGZIP(), []int{2}
+	return file_backend_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Log) GetLvl() Log_Level {
@@ -274,7 +490,7 @@ type Log_Trace struct {
 func (x *Log_Trace) Reset() {
 	*x = Log_Trace{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_backend_proto_msgTypes[3]
+		mi := &file_backend_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -287,7 +503,7 @@ func (x *Log_Trace) String() string {
 func (*Log_Trace) ProtoMessage() {}
 
 func (x *Log_Trace) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_msgTypes[3]
+	mi := &file_backend_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -300,7 +516,7 @@ func (x *Log_Trace) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Log_Trace.ProtoReflect.Descriptor instead.
 func (*Log_Trace) Descriptor() ([]byte, []int) {
-	return file_backend_proto_rawDescGZIP(), []int{2, 0}
+	return file_backend_proto_rawDescGZIP(), []int{4, 0}
 }
 
 func (x *Log_Trace) GetMessage() string {
@@ -339,7 +555,7 @@ type Log_Trace_KeyValue struct {
 func (x *Log_Trace_KeyValue) Reset() {
 	*x = Log_Trace_KeyValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_backend_proto_msgTypes[4]
+		mi := &file_backend_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -352,7 +568,7 @@ func (x *Log_Trace_KeyValue) String() string {
 func (*Log_Trace_KeyValue) ProtoMessage() {}
 
 func (x *Log_Trace_KeyValue) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_msgTypes[4]
+	mi := &file_backend_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -365,7 +581,7 @@ func (x *Log_Trace_KeyValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Log_Trace_KeyValue.ProtoReflect.Descriptor instead.
 func (*Log_Trace_KeyValue) Descriptor() ([]byte, []int) {
-	return file_backend_proto_rawDescGZIP(), []int{2, 0, 0}
+	return file_backend_proto_rawDescGZIP(), []int{4, 0, 0}
 }
 
 func (x *Log_Trace_KeyValue) GetKey() string {
@@ -423,7 +639,7 @@ type Log_Trace_Node struct {
 func (x *Log_Trace_Node) Reset() {
 	*x = Log_Trace_Node{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_backend_proto_msgTypes[5]
+		mi := &file_backend_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -436,7 +652,7 @@ func (x *Log_Trace_Node) String() string {
 func (*Log_Trace_Node) ProtoMessage() {}
 
 func (x *Log_Trace_Node) ProtoReflect() protoreflect.Message {
-	mi := &file_backend_proto_msgTypes[5]
+	mi := &file_backend_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -449,7 +665,7 @@ func (x *Log_Trace_Node) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Log_Trace_Node.ProtoReflect.Descriptor instead.
 func (*Log_Trace_Node) Descriptor() ([]byte, []int) {
-	return file_backend_proto_rawDescGZIP(), []int{2, 0, 1}
+	return file_backend_proto_rawDescGZIP(), []int{4, 0, 1}
 }
 
 func (x *Log_Trace_Node) GetPath() []int32 {
@@ -463,7 +679,31 @@ var File_backend_proto protoreflect.FileDescriptor
 
 var file_backend_proto_rawDesc = []byte{
 	0x0a, 0x0d, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x0d, 0x6b, 0x62, 0x2e, 0x69, 0x72, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x22, 0x69,
+	0x0d, 0x6b, 0x62, 0x2e, 0x69, 0x72, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x1a, 0x0e,
+	0x65, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0b,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf8, 0x01, 0x0a, 0x07,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6b, 0x62, 0x2e, 0x69, 0x72, 0x2e,
+	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x72, 0x6f, 0x6f, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72,
+	0x6f, 0x6f, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6b, 0x62, 0x2e, 0x69, 0x72, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x2e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x05, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x12, 0x38, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6b, 0x62, 0x2e, 0x69, 0x72, 0x2e, 0x62,
+	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x52, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x44, 0x69, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x63,
+	0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x72, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x72, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x66, 0x0a, 0x09, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65,
+	0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x03, 0x73, 0x74, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x73, 0x74, 0x72, 0x12, 0x14, 0x0a, 0x04, 0x62,
+	0x6f, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x04, 0x62, 0x6f, 0x6f,
+	0x6c, 0x12, 0x12, 0x0a, 0x03, 0x69, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00,
+	0x52, 0x03, 0x69, 0x6e, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x69,
 	0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x03, 0x6c, 0x6f,
 	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6b, 0x62, 0x2e, 0x69, 0x72, 0x2e,
 	0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x4c, 0x6f, 0x67, 0x48, 0x00, 0x52, 0x03, 0x6c,
@@ -474,7 +714,7 @@ var file_backend_proto_rawDesc = []byte{
 	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
 	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x73, 0x22, 0xa8, 0x03, 0x0a, 0x03, 0x4c, 0x6f, 0x67, 0x12, 0x2a, 0x0a, 0x03, 0x6c, 0x76, 0x6c,
+	0x73, 0x22, 0xc0, 0x03, 0x0a, 0x03, 0x4c, 0x6f, 0x67, 0x12, 0x2a, 0x0a, 0x03, 0x6c, 0x76, 0x6c,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x6b, 0x62, 0x2e, 0x69, 0x72, 0x2e, 0x62,
 	0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2e, 0x4c, 0x6f, 0x67, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c,
 	0x52, 0x03, 0x6c, 0x76, 0x6c, 0x12, 0x2e, 0x0a, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x18, 0x02,
@@ -498,12 +738,13 @@ var file_backend_proto_rawDesc = []byte{
 	0x67, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x48, 0x00, 0x52, 0x09,
 	0x6f, 0x74, 0x68, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c,
 	0x75, 0x65, 0x1a, 0x1a, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61,
-	0x74, 0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x1c,
+	0x74, 0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x34,
 	0x0a, 0x05, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e, 0x46, 0x4f, 0x10,
-	0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x42, 0x21, 0x5a, 0x1f,
-	0x6b, 0x38, 0x73, 0x2e, 0x69, 0x6f, 0x2f, 0x69, 0x64, 0x6c, 0x2f, 0x63, 0x6b, 0x64, 0x6c, 0x2d,
-	0x69, 0x72, 0x2f, 0x67, 0x6f, 0x69, 0x72, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07,
+	0x57, 0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x45, 0x42,
+	0x55, 0x47, 0x10, 0x03, 0x42, 0x21, 0x5a, 0x1f, 0x6b, 0x38, 0x73, 0x2e, 0x69, 0x6f, 0x2f, 0x69,
+	0x64, 0x6c, 0x2f, 0x63, 0x6b, 0x64, 0x6c, 0x2d, 0x69, 0x72, 0x2f, 0x67, 0x6f, 0x69, 0x72, 0x2f,
+	0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -519,29 +760,36 @@ func file_backend_proto_rawDescGZIP() []byte {
 }
 
 var file_backend_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_backend_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_backend_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_backend_proto_goTypes = []interface{}{
 	(Log_Level)(0),             // 0: kb.ir.backend.Log.Level
-	(*Response)(nil),           // 1: kb.ir.backend.Response
-	(*File)(nil),               // 2: kb.ir.backend.File
-	(*Log)(nil),                // 3: kb.ir.backend.Log
-	(*Log_Trace)(nil),          // 4: kb.ir.backend.Log.Trace
-	(*Log_Trace_KeyValue)(nil), // 5: kb.ir.backend.Log.Trace.KeyValue
-	(*Log_Trace_Node)(nil),     // 6: kb.ir.backend.Log.Trace.Node
+	(*Request)(nil),            // 1: kb.ir.backend.Request
+	(*Parameter)(nil),          // 2: kb.ir.backend.Parameter
+	(*Response)(nil),           // 3: kb.ir.backend.Response
+	(*File)(nil),               // 4: kb.ir.backend.File
+	(*Log)(nil),                // 5: kb.ir.backend.Log
+	(*Log_Trace)(nil),          // 6: kb.ir.backend.Log.Trace
+	(*Log_Trace_KeyValue)(nil), // 7: kb.ir.backend.Log.Trace.KeyValue
+	(*Log_Trace_Node)(nil),     // 8: kb.ir.backend.Log.Trace.Node
+	(*goir.Bundle)(nil),        // 9: kb.ir.Bundle
+	(*types.Reference)(nil),    // 10: kb.ir.types.Reference
 }
 var file_backend_proto_depIdxs = []int32{
-	3, // 0: kb.ir.backend.Response.log:type_name -> kb.ir.backend.Log
-	2, // 1: kb.ir.backend.Response.result:type_name -> kb.ir.backend.File
-	0, // 2: kb.ir.backend.Log.lvl:type_name -> kb.ir.backend.Log.Level
-	4, // 3: kb.ir.backend.Log.trace:type_name -> kb.ir.backend.Log.Trace
-	5, // 4: kb.ir.backend.Log.Trace.values:type_name -> kb.ir.backend.Log.Trace.KeyValue
-	6, // 5: kb.ir.backend.Log.Trace.node:type_name -> kb.ir.backend.Log.Trace.Node
-	6, // 6: kb.ir.backend.Log.Trace.KeyValue.other_node:type_name -> kb.ir.backend.Log.Trace.Node
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	9,  // 0: kb.ir.backend.Request.bundle:type_name -> kb.ir.Bundle
+	10, // 1: kb.ir.backend.Request.types:type_name -> kb.ir.types.Reference
+	2,  // 2: kb.ir.backend.Request.parameters:type_name -> kb.ir.backend.Parameter
+	5,  // 3: kb.ir.backend.Response.log:type_name -> kb.ir.backend.Log
+	4,  // 4: kb.ir.backend.Response.result:type_name -> kb.ir.backend.File
+	0,  // 5: kb.ir.backend.Log.lvl:type_name -> kb.ir.backend.Log.Level
+	6,  // 6: kb.ir.backend.Log.trace:type_name -> kb.ir.backend.Log.Trace
+	7,  // 7: kb.ir.backend.Log.Trace.values:type_name -> kb.ir.backend.Log.Trace.KeyValue
+	8,  // 8: kb.ir.backend.Log.Trace.node:type_name -> kb.ir.backend.Log.Trace.Node
+	8,  // 9: kb.ir.backend.Log.Trace.KeyValue.other_node:type_name -> kb.ir.backend.Log.Trace.Node
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_backend_proto_init() }
@@ -551,7 +799,7 @@ func file_backend_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_backend_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Response); i {
+			switch v := v.(*Request); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -563,7 +811,7 @@ func file_backend_proto_init() {
 			}
 		}
 		file_backend_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*File); i {
+			switch v := v.(*Parameter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -575,7 +823,7 @@ func file_backend_proto_init() {
 			}
 		}
 		file_backend_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Log); i {
+			switch v := v.(*Response); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -587,7 +835,7 @@ func file_backend_proto_init() {
 			}
 		}
 		file_backend_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Log_Trace); i {
+			switch v := v.(*File); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -599,7 +847,7 @@ func file_backend_proto_init() {
 			}
 		}
 		file_backend_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Log_Trace_KeyValue); i {
+			switch v := v.(*Log); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -611,6 +859,30 @@ func file_backend_proto_init() {
 			}
 		}
 		file_backend_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Log_Trace); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Log_Trace_KeyValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backend_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Log_Trace_Node); i {
 			case 0:
 				return &v.state
@@ -623,11 +895,16 @@ func file_backend_proto_init() {
 			}
 		}
 	}
-	file_backend_proto_msgTypes[0].OneofWrappers = []interface{}{
+	file_backend_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*Parameter_Str)(nil),
+		(*Parameter_Bool)(nil),
+		(*Parameter_Int)(nil),
+	}
+	file_backend_proto_msgTypes[2].OneofWrappers = []interface{}{
 		(*Response_Log)(nil),
 		(*Response_Result)(nil),
 	}
-	file_backend_proto_msgTypes[4].OneofWrappers = []interface{}{
+	file_backend_proto_msgTypes[6].OneofWrappers = []interface{}{
 		(*Log_Trace_KeyValue_Str)(nil),
 		(*Log_Trace_KeyValue_OtherNode)(nil),
 	}
@@ -637,7 +914,7 @@ func file_backend_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_backend_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   6,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
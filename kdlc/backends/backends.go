@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+
+// Package backends finds the backend for an output format.  Formats are
+// either registered with the compiler (the built-in backends register
+// themselves), or run as a ckdl-to-FORMAT command found on the path.
+package backends
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"k8s.io/idl/backends/common/backend"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]backend.Backend)
+)
+
+// Register makes a backend available in-process under the given output
+// format name.  It panics if the name is already taken.
+func Register(name string, b backend.Backend) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[name]; exists {
+		panic(fmt.Sprintf("backend %q registered twice", name))
+	}
+	registry[name] = b
+}
+
+// Lookup returns the backend for the given output format: the registered
+// one if it exists, or else one that runs ckdl-to-NAME.
+func Lookup(name string) backend.Backend {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if b, exists := registry[name]; exists {
+		return b
+	}
+	return Exec{Command: "ckdl-to-" + name}
+}
+
+// Registered lists the names of the registered backends, in order.
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package backends
+
+import (
+	"k8s.io/idl/backends/inspect"
+	"k8s.io/idl/backends/markerproto"
+	"k8s.io/idl/backends/tocrd"
+	"k8s.io/idl/backends/tokgo"
+	"k8s.io/idl/backends/tosmd"
+)
+
+// the built-in backends go by the same names as their commands, so that
+// switching between the two doesn't change how kdlc is invoked.
+func init() {
+	Register("inspect", inspect.Backend)
+	Register("markerproto", markerproto.Backend)
+	Register("tocrd", tocrd.Backend)
+	Register("tokgo", tokgo.Backend)
+	Register("smd", tosmd.Backend)
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package backends
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"k8s.io/idl/backends/common/backend"
+	"k8s.io/idl/backends/common/respond"
+	irb "k8s.io/idl/ckdl-ir/goir/backend"
+)
+
+// Exec is a backend run as its own command, which reads the request from
+// stdin and writes responses to stdout.
+type Exec struct {
+	// Command is the name of (or path to) the command to run.
+	Command string
+}
+
+func (e Exec) Generate(ctx context.Context, req *irb.Request, out respond.Sink) error {
+	reqOut, err := proto.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("unable to serialize backend request: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, e.Command)
+	cmd.Stdin = bytes.NewReader(reqOut)
+	cmdOut, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("unable to set up command %q: %w", cmd.String(), err)
+	}
+	cmdErr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("unable to set up command %q: %w", cmd.String(), err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("unable to run command %q: %w", cmd.String(), err)
+	}
+
+	// responses & stderr arrive concurrently, but the sink expects them
+	// one at a time
+	var (
+		outMu sync.Mutex
+		loggedErrs bool
+	)
+	send := func(msg *irb.Response) {
+		outMu.Lock()
+		defer outMu.Unlock()
+		if log := msg.GetLog(); log != nil && log.Lvl == irb.Log_ERROR {
+			loggedErrs = true
+		}
+		out(msg)
+	}
+
+	// pass stderr along as it arrives, so that progress & panics from the
+	// backend show up in order with everything else
+	stderrDone := make(chan struct{})
+	go func() {
+		defer close(stderrDone)
+		forwardStderr(cmdErr, respond.New(send))
+	}()
+
+	// likewise, handle responses as they arrive instead of waiting for the
+	// backend to finish, since there can be a lot of them
+	dec := respond.NewDecoder(cmdOut)
+	var readErr error
+	for {
+		msg := new(irb.Response)
+		if err := dec.Decode(msg); err != nil {
+			if err != io.EOF {
+				readErr = err
+			}
+			break
+		}
+		send(msg)
+	}
+	if readErr != nil {
+		// nothing after a bad response can be trusted, but we need to keep
+		// reading so that the backend isn't stuck writing to a full pipe
+		io.Copy(ioutil.Discard, cmdOut)
+	}
+
+	// the pipes are closed by Wait, so we need to be done reading first
+	<-stderrDone
+	runErr := cmd.Wait()
+
+	if runErr != nil {
+		if loggedErrs && readErr == nil {
+			// backend.Main exits non-zero after logging why
+			return backend.ErrReported
+		}
+		return fmt.Errorf("error running command %q: %w", cmd.String(), runErr)
+	}
+	if readErr != nil {
+		return fmt.Errorf("invalid response from command %q: %w", cmd.String(), readErr)
+	}
+	return nil
+}
+
+// forwardStderr logs each line the backend writes to stderr.
+func forwardStderr(stderr io.Reader, resp *respond.Responder) {
+	lines := bufio.NewReader(stderr)
+	for {
+		line, err := lines.ReadString('\n')
+		if line = strings.TrimRight(line, "\r\n"); line != "" {
+			resp.GeneralInfo(line)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
@@ -1,17 +1,60 @@
 module k8s.io/idl/kdlc
 
-go 1.15
+go 1.22.0
 
 require (
-	github.com/golang/protobuf v1.4.3
+	github.com/golang/protobuf v1.5.3
+	github.com/google/cel-go v0.16.1
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/spf13/pflag v1.0.5
-	google.golang.org/protobuf v1.25.0
-	gopkg.in/yaml.v2 v2.4.0
+	google.golang.org/genproto/googleapis/api v0.0.0-20230525234035-dd9d682886f9
+	google.golang.org/protobuf v1.30.0
+	gopkg.in/yaml.v3 v3.0.1
 	k8s.io/idl/backends/common v0.0.0-00010101000000-000000000000
+	k8s.io/idl/backends/inspect v0.0.0-00010101000000-000000000000
+	k8s.io/idl/backends/markerproto v0.0.0-00010101000000-000000000000
+	k8s.io/idl/backends/tocrd v0.0.0-00010101000000-000000000000
+	k8s.io/idl/backends/tokgo v0.0.0-00010101000000-000000000000
+	k8s.io/idl/backends/tosmd v0.0.0-00010101000000-000000000000
 	k8s.io/idl/ckdl-ir/goir v0.0.0-00010101000000-000000000000
 )
 
+require (
+	github.com/antlr/antlr4/runtime/Go/antlr/v4 v4.0.0-20230305170008-8188dc5388df // indirect
+	github.com/go-logr/logr v1.2.4 // indirect
+	github.com/gobuffalo/flect v0.2.2 // indirect
+	github.com/gogo/protobuf v1.3.2 // indirect
+	github.com/google/gofuzz v1.2.0 // indirect
+	github.com/json-iterator/go v1.1.12 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
+	github.com/stoewer/go-strcase v1.2.0 // indirect
+	golang.org/x/exp v0.0.0-20220827204233-334a2380cb91 // indirect
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/net v0.30.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+	golang.org/x/text v0.19.0 // indirect
+	golang.org/x/tools v0.26.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20230525234030-28d5490b6b19 // indirect
+	gopkg.in/inf.v0 v0.9.1 // indirect
+	gopkg.in/yaml.v2 v2.4.0 // indirect
+	k8s.io/apiextensions-apiserver v0.28.3 // indirect
+	k8s.io/apimachinery v0.28.3 // indirect
+	k8s.io/klog/v2 v2.100.1 // indirect
+	k8s.io/utils v0.0.0-20230406110748-d93618cff8a2 // indirect
+	sigs.k8s.io/controller-tools v0.4.1 // indirect
+	sigs.k8s.io/json v0.0.0-20221116044647-bc3834ca7abd // indirect
+	sigs.k8s.io/structured-merge-diff/v4 v4.2.3 // indirect
+	sigs.k8s.io/yaml v1.3.0 // indirect
+)
+
 replace (
 	k8s.io/idl/backends/common => ../backends/common
+	k8s.io/idl/backends/inspect => ../backends/inspect
+	k8s.io/idl/backends/markerproto => ../backends/markerproto
+	k8s.io/idl/backends/tocrd => ../backends/tocrd
+	k8s.io/idl/backends/tokgo => ../backends/tokgo
+	k8s.io/idl/backends/tosmd => ../backends/tosmd
 	k8s.io/idl/ckdl-ir/goir => ../ckdl-ir/goir
+	k8s.io/idl/kdlc => ./
 )
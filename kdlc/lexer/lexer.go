@@ -444,13 +444,18 @@ func (l *Lexer) scanString(ctx context.Context) Token {
 
 func (l *Lexer) scanFieldPath(ctx context.Context) Token {
 	start := l.sc.Pos()
-	l.consumeCh() // skip the dot
 
-	if !l.expectThat(ctx, unicode.IsLower, "Lu (lower case letter to start field name)") {
-		return Token{Start: start, End: l.sc.Pos(), Type: FieldPath}
-	}
+	// field paths may be nested (`.spec.replicas`), so keep going
+	// as long as we see another dot
+	for l.peekCh() == '.' {
+		l.consumeCh() // skip the dot
 
-	l.consumeWhile(func(ch rune) bool { return unicode.IsLetter(ch) || unicode.IsDigit(ch) })
+		if !l.expectThat(ctx, unicode.IsLower, "Lu (lower case letter to start field name)") {
+			return Token{Start: start, End: l.sc.Pos(), Type: FieldPath}
+		}
+
+		l.consumeWhile(func(ch rune) bool { return unicode.IsLetter(ch) || unicode.IsDigit(ch) })
+	}
 	return Token{Start: start, End: l.sc.Pos(), Type: FieldPath}
 }
 
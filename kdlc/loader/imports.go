@@ -30,12 +30,18 @@ type Config struct {
 	Roots []string
 
 	Outputs Outputs
+	// Sources are the contents of the files that were compiled from KDL
+	// source, by virtual path, for pointing at them in later errors.
+	Sources map[string][]byte
 }
 func (c *Config) Load(ctx context.Context) {
 	l := &loader{
 		Imports: c.Imports,
+		Sources: make(map[string][]byte),
 	}
+	c.Sources = l.Sources
 	l.Graph = typecheck.NewGraph(l)
+	l.Graph.Sources = l.Sources
 
 	// manually add the roots to get the ball rolling
 	for _, rootPath := range c.Roots {
@@ -65,6 +71,7 @@ func (c *Config) Load(ctx context.Context) {
 type loader struct {
 	Imports Loader
 	Graph *typecheck.Graph
+	Sources map[string][]byte
 }
 
 func (l *loader) MaybeLoad(ctx context.Context, path string) *ire.Partial {
@@ -93,6 +100,7 @@ func (l *loader) Load(ctx context.Context, path string) *ire.Partial {
 		if preCompiled == nil {
 			preCompiled = &ire.Partial{}
 		}
+		l.Graph.AddMarkerSets(ctx, path, preCompiled.MarkerSets)
 		return preCompiled
 	}
 
@@ -104,6 +112,8 @@ func (l *loader) Load(ctx context.Context, path string) *ire.Partial {
 		return &ire.Partial{}
 	}
 
+	l.Sources[path] = rawSource
+
 	lex := lexer.New(bytes.NewBuffer(rawSource))
 	parse := parser.New(lex)
 	ctx = trace.WithFullInput(ctx, string(rawSource)) // todo: put in lookaside instead?
@@ -112,5 +122,6 @@ func (l *loader) Load(ctx context.Context, path string) *ire.Partial {
 		return &ire.Partial{}
 	}
 	res := passes.FileToIR(ctx, file, l)
+	l.Graph.AddMarkerSets(ctx, path, res.MarkerSets)
 	return &res
 }
@@ -6,17 +6,19 @@ import (
 	"strings"
 	"fmt"
 	"os"
-	"os/exec"
 	"context"
-	"bytes"
+	"errors"
+	"sync"
+	"runtime/debug"
 	"path/filepath"
 
 	flag "github.com/spf13/pflag"
 
 	"k8s.io/idl/kdlc/loader"
 	"k8s.io/idl/kdlc/parser/trace"
+	"k8s.io/idl/kdlc/backends"
 	irb "k8s.io/idl/ckdl-ir/goir/backend"
-	"k8s.io/idl/backends/common/respond"
+	"k8s.io/idl/backends/common/backend"
 
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
@@ -25,17 +27,19 @@ import (
 var (
 	importPaths = flag.StringArrayP("import-dir", "i", nil, "root KDL & cKDL import paths")
 	importBundles = flag.StringArrayP("import-bundle", "B", nil, "import from CKDL bundle(s)")
-	outputFormat = flag.StringP("output", "o", "bundle", "what to output (bundle, or xyz, where ckdl-to-xyz is an executable on your path)")
-	outputArgs = flag.StringArrayP("output-arg", "t", nil, "arguments to pass to the output plugin (e.g. group/version::Type for ckdl-to-crd)")
-	outputDir = flag.StringP("output-dir", "d", "", "path to output files from the output format relative to (defaults to the current directory)")
-	verbose = flag.BoolP("verbose", "v", false, "whether to output the results as textproto to stderr")
+	verbose = flag.CountP("verbose", "v", verboseUsage)
+	verifyOnly = flag.Bool("verify", false, "check that the files in each output directory match what would be generated, instead of writing them, exiting non-zero if they don't")
+	showDiff = flag.Bool("diff", false, "like --verify, but also print a unified diff of each file that doesn't match")
+	prune = flag.Bool("prune", false, "remove files that an output generated previously but no longer does")
 
 	importPartials = new(mapValue)
 	// cacheBehavior = &cacheBehaviorVal{Behavior: "alongside"}
 	cacheBehavior = &cacheBehaviorVal{Behavior: "none"} // TODO: eventually alongside
-	outputFlags = new(mapValue)
+	outputs = new(outputList)
 )
 
+const verboseUsage = "show debug logs from backends, and (if given twice) the compiled bundle as textproto on stderr"
+
 type cacheBehaviorVal struct {
 	Behavior string
 	Dir string
@@ -136,13 +140,22 @@ func (v *mapValue) String() string {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "build" {
+		buildMain(os.Args[2:])
+		return
+	}
+
 	flag.VarP(importPartials, "import-partial", "I", "import from CKDL(s) partial files")
 	flag.Var(cacheBehavior, "cache", "where to read/write cKDL partial files from/to")
-	flag.VarP(outputFlags, "output-flag", "f", "flags to pass to the output plugin (`key=value` becomes `--kdl-key=value`)")
+	flag.VarP(outputFormatValue{outputs}, "output", "o", "what to output (bundle, a built-in backend ("+strings.Join(backends.Registered(), ", ")+"), or xyz, where ckdl-to-xyz is an executable on your path).  May be repeated to run several backends on the same compiled output.  Defaults to bundle")
+	flag.VarP(outputTypeValue{outputs}, "output-arg", "t", "types to generate for (e.g. group/version::Type for tocrd), for the preceding --output, or for all outputs if given before any")
+	flag.VarP(outputFlagValue{outputs}, "output-flag", "f", "options to pass to the preceding --output (or to all outputs, if given before any), as `key=value` (true, false, and integers are passed as such)")
+	flag.VarP(outputDirValue{outputs}, "output-dir", "d", "path to output files from the preceding --output (or from all outputs, if given before any) relative to, or - for stdout (defaults to the current directory)")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "usage: %s [FLAGS...] VIRTUALPATH...\n", os.Args[0])
-		fmt.Fprintln(os.Stderr, "where VIRTUALPATH are the import-root-relative paths to KDL files to start from")
+		fmt.Fprintf(os.Stderr, "   or: %s build [FLAGS...] [TARGET...]\n", os.Args[0])
+		fmt.Fprintln(os.Stderr, "where VIRTUALPATH are the import-root-relative paths to KDL files to start from, and TARGET are targets from a project file (see build --help)")
 		flag.PrintDefaults()
 	}
 	flag.Parse()
@@ -152,54 +165,79 @@ func main() {
 		os.Exit(1)
 	}
 
-	if *outputDir == "" {
+	if outputs.shared.Dir == "" {
 		cwd, err := os.Getwd()
 		if err != nil {
 			panic(fmt.Sprintf("no output directory specified, unable to determine current working directory: %v", err))
 		}
-		*outputDir = cwd
+		outputs.shared.Dir = cwd
 	}
 
+	build(buildConfig{
+		ImportPaths: *importPaths,
+		ImportBundles: *importBundles,
+		ImportPartials: *importPartials,
+		Cache: *cacheBehavior,
+		Roots: flag.Args(),
+		Outputs: outputs.Resolve(),
+	})
+}
+
+// buildConfig is everything needed to compile some KDL & run backends on
+// the result, whether it came from flags or from a project file.
+type buildConfig struct {
+	ImportPaths []string
+	ImportBundles []string
+	ImportPartials mapValue
+	Cache cacheBehaviorVal
+	// Roots are the virtual paths of the files to compile.
+	Roots []string
+	Outputs []*output
+}
+
+// build compiles the configured roots & runs each output on the result,
+// exiting non-zero if compilation or any of the outputs fail.
+func build(cfg buildConfig) {
 	compiledImp := &loader.CompiledLoader{
-		BundlePaths: *importBundles,
+		BundlePaths: cfg.ImportBundles,
 		DescFilePaths: make(map[string]string),
 	}
-	for i, kdlPath := range importPartials.Keys {
+	for i, kdlPath := range cfg.ImportPartials.Keys {
 		// TODO: validate not specified twice?
-		ckdlPath := importPartials.Values[i]
+		ckdlPath := cfg.ImportPartials.Values[i]
 		compiledImp.DescFilePaths[kdlPath] = ckdlPath
 	}
-	switch cacheBehavior.Behavior { 
+	switch cfg.Cache.Behavior { 
 	case "none":
 		// do nothing
 	case "alongside-always":
-		compiledImp.ImportRoots = *importPaths
+		compiledImp.ImportRoots = cfg.ImportPaths
 		compiledImp.AlwaysUse = true
 	default:
 		panic("TODO: cache support")
 	}
 
-	cfg := loader.Config{
-		Roots: flag.Args(),
+	loadCfg := loader.Config{
+		Roots: cfg.Roots,
 		Imports: &loader.HybridLoader{
-			Source: loader.SourceLoader{Roots: *importPaths},
+			Source: loader.SourceLoader{Roots: cfg.ImportPaths},
 			Compiled: compiledImp,
 		},
 	}
 
 	ctx := trace.RecordError(context.Background())
-	cfg.Load(ctx)
+	loadCfg.Load(ctx)
 
 	if trace.HadError(ctx) {
 		os.Exit(1)
 	}
 
-	bundle := cfg.Outputs.BundleFor(ctx, flag.Args()...)
+	bundle := loadCfg.Outputs.BundleFor(ctx, cfg.Roots...)
 	if bundle == nil {
 		os.Exit(1)
 	}
 
-	if *verbose {
+	if *verbose >= 2 {
 		out, err := prototext.MarshalOptions{
 			Multiline: true,
 		}.Marshal(bundle)
@@ -209,107 +247,243 @@ func main() {
 		fmt.Fprintf(os.Stderr, string(out))
 	}
 
-	bundleOut, err := proto.Marshal(bundle)
-	if err != nil {
-		panic(err)
+	// build every request up front, so that bad arguments for one output
+	// don't leave the others half-run
+	toRun := cfg.Outputs
+	reqs := make([]*irb.Request, len(toRun))
+	for i, out := range toRun {
+		if out.Format == "bundle" {
+			continue
+		}
+		req, err := out.Request(bundle, cfg.Roots)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", out.DisplayName(), err)
+			os.Exit(1)
+		}
+		reqs[i] = req
+	}
+
+	if err := checkManifestKeys(toRun); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 
-	if *outputFormat == "bundle" {
-		if _, err := os.Stdout.Write(bundleOut); err != nil {
-			panic(err)
+	var verify *verifier
+	if *verifyOnly || *showDiff {
+		verify = &verifier{ShowDiff: *showDiff}
+	}
+
+	// run the backend for each FORMAT concurrently, either in-process or by
+	// exec-ing ckdl-to-FORMAT
+	var (
+		wg sync.WaitGroup
+		writeMu sync.Mutex
+		runErrs = make([]error, len(toRun))
+		writers = make([]*responseWriter, len(toRun))
+	)
+	for i, out := range toRun {
+		if out.Format == "bundle" {
+			if verify != nil {
+				// the bundle only goes to stdout, so there's nothing to check
+				continue
+			}
+			bundleOut, err := proto.Marshal(bundle)
+			if err != nil {
+				panic(err)
+			}
+			writeMu.Lock()
+			_, err = os.Stdout.Write(bundleOut)
+			writeMu.Unlock()
+			if err != nil {
+				panic(err)
+			}
+			continue
 		}
-		return
+		if verify != nil && out.Dir == "-" {
+			fmt.Fprintf(os.Stderr, "%s: cannot verify output written to stdout\n", out.DisplayName())
+			os.Exit(1)
+		}
+
+		writers[i] = &responseWriter{
+			out: out,
+			mu: &writeMu,
+			verify: verify,
+			src: sourceLocator{bundle: bundle, sources: loadCfg.Sources},
+			threshold: logThreshold(),
+		}
+		wg.Add(1)
+		go func(i int, out *output) {
+			defer wg.Done()
+			runErrs[i] = backends.Lookup(out.Format).Generate(ctx, reqs[i], writers[i].Write)
+		}(i, out)
 	}
+	wg.Wait()
 
-	// exec ckdl-to-FORMAT [flags...] args...
-	cmdName := "ckdl-to-"+*outputFormat
-	var args []string
-	for i, flagName := range outputFlags.Keys {
-		flagVal := outputFlags.Values[i]
-		args = append(args, "--%s=%s", flagName, flagVal)
+	failed := false
+	var total logCounts
+	for i, runErr := range runErrs {
+		if writers[i] != nil {
+			counts := writers[i].logged
+			total.add(counts)
+			if len(writers[i].errs) > 0 {
+				failed = true
+				fmt.Fprintf(os.Stderr, "backend %q produced files that couldn't be written\n", toRun[i].DisplayName())
+			}
+			if runErr == nil && counts[irb.Log_ERROR] > 0 {
+				// the backend finished, but what it produced can't be
+				// trusted
+				failed = true
+				fmt.Fprintf(os.Stderr, "backend %q failed\n", toRun[i].DisplayName())
+			}
+		}
+		if runErr == nil {
+			continue
+		}
+		failed = true
+		if !errors.Is(runErr, backend.ErrReported) {
+			fmt.Fprintf(os.Stderr, "unable to run backend %q: %v\n", toRun[i].DisplayName(), runErr)
+		} else {
+			fmt.Fprintf(os.Stderr, "backend %q failed\n", toRun[i].DisplayName())
+		}
+	}
+	if summary := total.String(); summary != "" {
+		fmt.Fprintln(os.Stderr, summary)
 	}
-	if len(*outputArgs) != 0 {
-		args = append(args, "--")
+	if failed {
+		os.Exit(1)
 	}
-	for _, arg := range *outputArgs {
-		args = append(args, arg)
+
+	if err := recordGenerated(toRun, writers, verify, *prune); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-	// TODO: StderrPipe
-	cmd := exec.Command(cmdName, args...)
-	cmdOut := new(bytes.Buffer)
-	cmdErr := new(bytes.Buffer)
-	cmd.Stdout = cmdOut
-	cmd.Stderr = cmdErr
-	cmd.Stdin = bytes.NewReader(bundleOut)
-
-	runErr := cmd.Run()
-	if runErr != nil {
-		fmt.Fprintf(os.Stderr, "error running command %q:\n\t%s\n\n", cmd.String(), cmdErr.String())
+	if verify != nil && verify.Failed() {
+		fmt.Fprintln(os.Stderr, "generated files are out of date")
+		os.Exit(1)
 	}
+}
 
-	msgsRaw := cmdOut.Bytes()
-	var msg irb.Response
-	for len(msgsRaw) > 0 {
-		msgsRaw = respond.Read(&msg, msgsRaw)
+// recordGenerated updates the manifest in each output directory with what
+// was just generated there, or (when verifying) reports files from the
+// manifest that are no longer generated.  Files that are no longer
+// generated are removed if pruning, and otherwise kept in the manifest so
+// that a later run can prune them.
+func recordGenerated(toRun []*output, writers []*responseWriter, verify *verifier, prune bool) error {
+	byDir, dirs := outputsByDir(toRun, writers)
+	for _, dir := range dirs {
+		prev, err := readManifest(dir)
+		if err != nil {
+			return err
+		}
 
-		switch msgWrapper := msg.Type.(type) {
-		case *irb.Response_Result:
-			file := msgWrapper.Result
-			if *outputDir == "-" {
-				fmt.Printf("---\n# %s\n%s\n", file.Name, string(file.Contents))
+		// work out what's stale from the manifest as it was before this run,
+		// against everything this run produced in the directory
+		produced := make(map[string]bool)
+		for _, i := range byDir[dir] {
+			for _, name := range writers[i].files {
+				produced[name] = true
+			}
+		}
+		stale := make([][]string, len(byDir[dir]))
+		for j, i := range byDir[dir] {
+			stale[j] = prev.Stale(toRun[i].ManifestKey(), produced)
+		}
+
+		next := &manifest{Outputs: make(map[string][]string, len(prev.Outputs))}
+		for key, files := range prev.Outputs {
+			next.Outputs[key] = files
+		}
+		for j, i := range byDir[dir] {
+			name := toRun[i].DisplayName()
+			if verify != nil {
+				verify.Stale(toRun[i], stale[j])
 				continue
 			}
 
-			outputPath := filepath.Join(*outputDir, filepath.FromSlash(file.Name))
-			// TODO(directxman12): there's no easy way to check "does this
-			// contain a ../" in it in a cross-platform way for now, so trust
-			// the generator for the moment.  Should fix later.
-			func() {
-				outFile, err := os.Create(outputPath)
+			files := writers[i].files
+			var kept int
+			for _, staleName := range stale[j] {
+				stalePath, err := outputPath(dir, staleName)
 				if err != nil {
-					// TODO
-					panic(err)
-				}
-				defer outFile.Close()
-				// TODO: ensure the directory exists
-				if _, err := outFile.Write(file.Contents); err != nil {
-					// TODO
-					panic(err)
+					// not something we'd have written, so just forget it
+					continue
 				}
-			}()
-		case *irb.Response_Log:
-			msg := msgWrapper.Log
-			fmt.Fprintf(os.Stderr, "[%s] ", msg.Lvl)
-			// TODO: unify with parser/trace logic
-			for i, tr := range msg.Trace {
-				if i != 0 {
-					fmt.Fprint(os.Stderr, "\t")
-				}
-				fmt.Fprintf(os.Stderr, "%s", tr.Message) // TODO
-				for _, kv := range tr.Values {
-					// TODO: other_node
-					switch val := kv.Value.(type) {
-					case *irb.Log_Trace_KeyValue_Str:
-						fmt.Fprintf(os.Stderr, " %s=%s", kv.Key, val.Str)
-					default:
-						// TODO
-						fmt.Fprintf(os.Stderr, " %s=<unsupported-%T>", kv.Key, val)
+				if prune {
+					if err := pruneOutputFile(dir, staleName); err != nil {
+						return fmt.Errorf("[%s] unable to prune %s: %w", name, staleName, err)
 					}
+					fmt.Fprintf(os.Stderr, "[%s] pruned %s\n", name, staleName)
+					continue
+				}
+				if _, err := os.Stat(stalePath); err == nil {
+					files = append(files, staleName)
+					kept++
 				}
-				fmt.Fprintln(os.Stderr, "")
-				// TODO: print node
 			}
-		default:
-			panic(fmt.Sprintf("unknown response type %T", msgWrapper))
+			if kept > 0 {
+				fmt.Fprintf(os.Stderr, "[%s] %d previously generated files are no longer generated (use --prune to remove them)\n", name, kept)
+			}
+			next.Outputs[toRun[i].ManifestKey()] = files
+		}
+		if verify != nil {
+			continue
+		}
+		if err := next.Write(dir); err != nil {
+			return err
 		}
 	}
-	fmt.Fprintln(os.Stderr, "")
-
-	// TODO: check for error logs
+	return nil
+}
 
-	if runErr != nil {
-		os.Exit(1)
+// outputsByDir groups the outputs that wrote to directories by directory,
+// returning the directories in the order they were first written to.
+func outputsByDir(toRun []*output, writers []*responseWriter) (map[string][]int, []string) {
+	byDir := make(map[string][]int)
+	var dirs []string
+	for i, out := range toRun {
+		if writers != nil && writers[i] == nil {
+			continue
+		}
+		if out.Format == "bundle" || out.Dir == "-" {
+			continue
+		}
+		dir := out.Dir
+		if absDir, err := filepath.Abs(dir); err == nil {
+			dir = absDir
+		}
+		if _, seen := byDir[dir]; !seen {
+			dirs = append(dirs, dir)
+		}
+		byDir[dir] = append(byDir[dir], i)
 	}
+	return byDir, dirs
+}
 
+// checkManifestKeys makes sure that no two outputs writing to the same
+// directory would share an entry in its manifest, since each would then
+// think the other's files were stale.
+func checkManifestKeys(toRun []*output) error {
+	byDir, dirs := outputsByDir(toRun, nil)
+	for _, dir := range dirs {
+		seen := make(map[string]*output)
+		for _, i := range byDir[dir] {
+			out := toRun[i]
+			key := out.ManifestKey()
+			if other, dup := seen[key]; dup {
+				return fmt.Errorf("outputs %s and %s would both write to %s with the same arguments", other.DisplayName(), out.DisplayName(), dir)
+			}
+			seen[key] = out
+		}
+	}
+	return nil
+}
 
+// compilerVersion returns the version kdlc was built at, which is
+// "(devel)" when built from a checkout.
+func compilerVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	return info.Main.Version
 }
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// manifestName is the file in each output directory that records what was
+// generated there.
+const manifestName = ".kdlc-manifest.json"
+
+// manifest records the files each output last generated into a directory,
+// so that we can tell when a file is no longer being generated.
+type manifest struct {
+	// Outputs maps output names to the slash-separated paths (relative to
+	// the directory) of the files they generated.
+	Outputs map[string][]string `json:"outputs"`
+}
+
+// readManifest loads the manifest for the given directory, returning an
+// empty one if nothing's been generated there yet.
+func readManifest(dir string) (*manifest, error) {
+	res := &manifest{Outputs: make(map[string][]string)}
+	raw, err := ioutil.ReadFile(filepath.Join(dir, manifestName))
+	if os.IsNotExist(err) {
+		return res, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read generated file manifest: %w", err)
+	}
+	if err := json.Unmarshal(raw, res); err != nil {
+		return nil, fmt.Errorf("unable to parse generated file manifest %s: %w", filepath.Join(dir, manifestName), err)
+	}
+	if res.Outputs == nil {
+		res.Outputs = make(map[string][]string)
+	}
+	return res, nil
+}
+
+// Write saves the manifest to the given directory.
+func (m *manifest) Write(dir string) error {
+	for _, files := range m.Outputs {
+		sort.Strings(files)
+	}
+	raw, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to serialize generated file manifest: %w", err)
+	}
+	raw = append(raw, '\n')
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("unable to write generated file manifest: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(dir, manifestName), raw); err != nil {
+		return fmt.Errorf("unable to write generated file manifest: %w", err)
+	}
+	return nil
+}
+
+// Stale returns the files the given output generated last time that no
+// output generated this time.  A file that's moved from one output to
+// another isn't stale.
+func (m *manifest) Stale(outputKey string, produced map[string]bool) []string {
+	var stale []string
+	for _, name := range m.Outputs[outputKey] {
+		if !produced[name] {
+			stale = append(stale, name)
+		}
+	}
+	return stale
+}
@@ -93,8 +93,15 @@ func typeToProto(ctx context.Context, raw *irm.Type, outType **pdesc.FieldDescri
 	case *irm.Type_NamedType:
 		trace.ErrorAt(ctx, "references are not supported in marker parameters (just yet)")
 	case *irm.Type_TypePrimitive:
-		// TODO: figure out how to encode these
-		trace.ErrorAt(ctx, "type values are not supported in marker parameters (just yet)")
+		switch typ.TypePrimitive {
+		case irm.TypePrimitive_FIELD_PATH:
+			// field paths are encoded as dot-separated strings, sans leading dot
+			primType := pdesc.FieldDescriptorProto_TYPE_STRING
+			*outType = &primType
+		default:
+			// TODO: figure out how to encode these
+			trace.ErrorAt(ctx, "type values are not supported in marker parameters (just yet)")
+		}
 	default:
 		panic(fmt.Sprintf("unreachable: unknown marker field type %T", typ))
 	}
@@ -0,0 +1,338 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	ir "k8s.io/idl/ckdl-ir/goir"
+	irb "k8s.io/idl/ckdl-ir/goir/backend"
+	"k8s.io/idl/backends/common/request"
+)
+
+// output is a single backend to run, along with its arguments.
+type output struct {
+	// Name identifies the output in logs, defaulting to its format.
+	Name string
+	Format string
+	// Types are the group/version::Type arguments (-t).
+	Types []string
+	// Flags are the backend options (-f).
+	Flags mapValue
+	// Dir is where to write files to (-d), "-" for stdout, or empty to use
+	// the shared (or default) directory.
+	Dir string
+}
+
+// outputList collects outputs from the command line.  -t, -f, and -d
+// apply to the most recent -o, or to every output when they come before
+// the first -o.
+type outputList struct {
+	shared output
+	outputs []*output
+}
+
+func (l *outputList) current() *output {
+	if len(l.outputs) == 0 {
+		return &l.shared
+	}
+	return l.outputs[len(l.outputs)-1]
+}
+
+// Resolve returns the outputs to run, with the shared arguments filled
+// in.  With no -o, it's a single bundle output.
+func (l *outputList) Resolve() []*output {
+	outputs := l.outputs
+	if len(outputs) == 0 {
+		outputs = []*output{{Format: "bundle"}}
+	}
+	res := make([]*output, len(outputs))
+	for i, out := range outputs {
+		merged := &output{
+			Name: out.Name,
+			Format: out.Format,
+			Types: append(append([]string(nil), l.shared.Types...), out.Types...),
+			Dir: out.Dir,
+		}
+		merged.Flags.Keys = append(append([]string(nil), l.shared.Flags.Keys...), out.Flags.Keys...)
+		merged.Flags.Values = append(append([]string(nil), l.shared.Flags.Values...), out.Flags.Values...)
+		if merged.Dir == "" {
+			merged.Dir = l.shared.Dir
+		}
+		res[i] = merged
+	}
+	return res
+}
+
+type outputFormatValue struct{ list *outputList }
+func (v outputFormatValue) Set(s string) error {
+	v.list.outputs = append(v.list.outputs, &output{Format: s})
+	return nil
+}
+func (v outputFormatValue) Type() string {
+	return "format"
+}
+func (v outputFormatValue) String() string {
+	formats := make([]string, len(v.list.outputs))
+	for i, out := range v.list.outputs {
+		formats[i] = out.Format
+	}
+	return strings.Join(formats, ",")
+}
+
+type outputTypeValue struct{ list *outputList }
+func (v outputTypeValue) Set(s string) error {
+	out := v.list.current()
+	out.Types = append(out.Types, s)
+	return nil
+}
+func (v outputTypeValue) Type() string {
+	return "group/version::Type"
+}
+func (v outputTypeValue) String() string {
+	return strings.Join(v.list.current().Types, ",")
+}
+
+type outputFlagValue struct{ list *outputList }
+func (v outputFlagValue) Set(s string) error {
+	return v.list.current().Flags.Append(s)
+}
+func (v outputFlagValue) Type() string {
+	return "key=value"
+}
+func (v outputFlagValue) String() string {
+	return v.list.current().Flags.String()
+}
+
+type outputDirValue struct{ list *outputList }
+func (v outputDirValue) Set(s string) error {
+	v.list.current().Dir = s
+	return nil
+}
+func (v outputDirValue) Type() string {
+	return "path"
+}
+func (v outputDirValue) String() string {
+	return v.list.current().Dir
+}
+
+func (o *output) DisplayName() string {
+	if o.Name != "" {
+		return o.Name
+	}
+	return o.Format
+}
+
+// ManifestKey identifies the output in the manifests of the directories it
+// writes to.  Unnamed outputs are identified by everything that affects
+// what they generate, so that several outputs of the same format (e.g.
+// tocrd for different types) can share a directory.
+func (o *output) ManifestKey() string {
+	if o.Name != "" {
+		return o.Name
+	}
+	types := append([]string(nil), o.Types...)
+	sort.Strings(types)
+	flags := o.Flags.GetSlice()
+	sort.Strings(flags)
+
+	parts := []string{o.Format}
+	for _, typ := range types {
+		parts = append(parts, "-t", typ)
+	}
+	for _, flag := range flags {
+		parts = append(parts, "-f", flag)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Request builds the request to send this output's backend.
+func (o *output) Request(bundle *ir.Bundle, roots []string) (*irb.Request, error) {
+	types, err := request.ParseTypes(o.Types...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output argument: %w", err)
+	}
+	req := &irb.Request{
+		Bundle: bundle,
+		Roots: roots,
+		CompilerVersion: compilerVersion(),
+	}
+	for _, typ := range types {
+		req.Types = append(req.Types, typ.Ref())
+	}
+	for i, flagName := range o.Flags.Keys {
+		req.Parameters = append(req.Parameters, request.ParseParameter(flagName, o.Flags.Values[i]))
+	}
+	if o.Dir != "-" {
+		absOut, err := filepath.Abs(o.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("unable to determine output directory: %w", err)
+		}
+		req.OutputDir = absOut
+	}
+	return req, nil
+}
+
+// responseWriter writes the files from a backend to its output directory
+// (or stdout, for an output directory of "-"), and prints its logs tagged
+// with the backend's name.  Backends run concurrently, so writers share a
+// lock.
+type responseWriter struct {
+	out *output
+	mu *sync.Mutex
+	// verify, if set, checks files against the output directory instead of
+	// writing them.
+	verify *verifier
+	// src finds the KDL that nodes in logs point at.
+	src sourceLocator
+	// threshold is the least severe log level to print.
+	threshold irb.Log_Level
+	// logged counts the logs the backend sent, printed or not.
+	logged logCounts
+
+	// files are the names of the files the backend produced.
+	files []string
+	// errs are the problems writing those files.
+	errs []error
+}
+
+// fail records (and reports) a file that couldn't be written.
+func (w *responseWriter) fail(err error) {
+	fmt.Fprintf(os.Stderr, "[%s] [ERROR] unable to write file: %v\n", w.out.DisplayName(), err)
+	w.errs = append(w.errs, err)
+}
+
+func (w *responseWriter) Write(msg *irb.Response) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	switch msgWrapper := msg.Type.(type) {
+	case *irb.Response_Result:
+		file := msgWrapper.Result
+		if w.out.Dir == "-" {
+			w.files = append(w.files, file.Name)
+			fmt.Printf("---\n# %s\n%s\n", file.Name, string(file.Contents))
+			return
+		}
+		if _, err := outputPath(w.out.Dir, file.Name); err != nil {
+			w.fail(err)
+			return
+		}
+		w.files = append(w.files, file.Name)
+		if w.verify != nil {
+			w.verify.Check(w.out, file)
+			return
+		}
+		if err := writeOutputFile(w.out.Dir, file.Name, file.Contents); err != nil {
+			w.fail(err)
+		}
+	case *irb.Response_Log:
+		msg := msgWrapper.Log
+		if w.logged == nil {
+			w.logged = make(logCounts)
+		}
+		// count levels we don't know about as errors too, so that they
+		// fail the run (and show up in the summary) like they're printed
+		counted := msg.Lvl
+		if logSeverity(counted) >= logSeverity(irb.Log_ERROR) {
+			counted = irb.Log_ERROR
+		}
+		w.logged[counted]++
+		if logSeverity(msg.Lvl) < logSeverity(w.threshold) {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "[%s] [%s] ", w.out.DisplayName(), msg.Lvl)
+		// TODO: unify with parser/trace logic
+		type nodeLoc struct {
+			desc string
+			loc sourceLoc
+		}
+		var locs []nodeLoc
+		for i, tr := range msg.Trace {
+			if i != 0 {
+				fmt.Fprint(os.Stderr, "\t")
+			}
+			fmt.Fprintf(os.Stderr, "%s", tr.Message) // TODO
+			if tr.Node != nil {
+				locs = append(locs, nodeLoc{desc: "at", loc: w.src.locate(tr.Node)})
+			}
+			for _, kv := range tr.Values {
+				switch val := kv.Value.(type) {
+				case *irb.Log_Trace_KeyValue_Str:
+					fmt.Fprintf(os.Stderr, " %s=%s", kv.Key, val.Str)
+				case *irb.Log_Trace_KeyValue_OtherNode:
+					loc := w.src.locate(val.OtherNode)
+					fmt.Fprintf(os.Stderr, " %s=%s", kv.Key, loc)
+					locs = append(locs, nodeLoc{desc: kv.Key+" at", loc: loc})
+				default:
+					fmt.Fprintf(os.Stderr, " %s=<unsupported-%T>", kv.Key, val)
+				}
+			}
+			fmt.Fprintln(os.Stderr, "")
+		}
+		for _, loc := range locs {
+			loc.loc.print(os.Stderr, loc.desc)
+		}
+	default:
+		panic(fmt.Sprintf("unknown response type %T", msgWrapper))
+	}
+}
+
+// logThreshold is the least severe backend log level to print, based on
+// the verbosity.
+func logThreshold() irb.Log_Level {
+	if *verbose > 0 {
+		return irb.Log_DEBUG
+	}
+	return irb.Log_INFO
+}
+
+// logSeverity orders log levels from least to most severe, since their
+// numbers in the protocol aren't.  Levels we don't know about (from newer
+// backends) are treated as errors, so they're never hidden.
+func logSeverity(lvl irb.Log_Level) int {
+	switch lvl {
+	case irb.Log_DEBUG:
+		return 0
+	case irb.Log_INFO:
+		return 1
+	case irb.Log_WARNING:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// logCounts counts logs by level.
+type logCounts map[irb.Log_Level]int
+
+func (c *logCounts) add(other logCounts) {
+	if *c == nil {
+		*c = make(logCounts)
+	}
+	for lvl, count := range other {
+		(*c)[lvl] += count
+	}
+}
+
+// String summarizes the errors & warnings, or returns the empty string if
+// there weren't any.
+func (c logCounts) String() string {
+	errs, warnings := c[irb.Log_ERROR], c[irb.Log_WARNING]
+	if errs == 0 && warnings == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s, %s", plural(errs, "error"), plural(warnings, "warning"))
+}
+
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
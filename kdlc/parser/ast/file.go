@@ -149,7 +149,9 @@ type KindDecl struct {
 	Fields []Field
 	Subtypes []SubtypeDecl
 
-	// TODO: nonpersisted
+	// Nonpersisted kinds aren't stored by the API server (e.g. review or
+	// options kinds), and thus don't have objectmeta
+	Nonpersisted bool
 
 	ResolvedName *ResolvedNameInfo
 
@@ -246,6 +248,8 @@ type Struct struct {
 	Fields []Field
 	Subtypes []SubtypeDecl
 
+	Atomic bool
+
 	trace.Span
 }
 type Union struct {
@@ -7,6 +7,7 @@ import (
 
 	ir "k8s.io/idl/ckdl-ir/goir/types"
 	irc "k8s.io/idl/ckdl-ir/goir/constraints"
+	irm "k8s.io/idl/ckdl-ir/goir/markers"
 )
 
 // TODO(directxman12): we could make all of this easier to expand & cleaner with reflection,
@@ -31,6 +32,9 @@ func (ListMapType) isModType() {}
 // simple-map(key: type, value: type)
 type PrimitiveMapType ir.PrimitiveMap
 func (PrimitiveMapType) isModType() {}
+// field-path (only valid in marker definitions)
+type MarkerPrimitiveType irm.TypePrimitive
+func (MarkerPrimitiveType) isModType() {}
 
 // validates(...)
 type ValidatesInfo struct {
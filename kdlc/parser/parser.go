@@ -265,9 +265,13 @@ func (p *Parser) parseValue(ctx context.Context) ast.Value {
 				Value: val,
 				Span: EndSpanAt(kvCtx, val.SpanEnd()),
 			})
+			if p.peek().Type != '}' {
+				// trailing comma is optional
+				p.expect(listCtx, ',')
+			}
 		})
 
-		res.Span = EndSpan(listCtx, p.expect(Describe(listCtx, "list end"), ']'))
+		res.Span = EndSpan(listCtx, p.expect(Describe(listCtx, "struct end"), '}'))
 		return res
 	case '[': // list
 		res := ast.ListVal{}
@@ -505,9 +509,12 @@ func (s *BoolParam) parse(ctx context.Context, p *Parser) {
 		s.Value = false
 	default:
 		p.markErrExp(ctx, tok, lexer.KWTrue, lexer.KWFalse)
+		s.set = true
+		s.Span = EndSpan(ctx, tok)
+		return
 	}
 	s.set = true
-	s.Span = EndSpan(ctx, tok)
+	s.Span = EndSpan(ctx, p.next(ctx))
 }
 func (s BoolParam) name() string {
 	return s.Name
@@ -533,7 +540,13 @@ func (p *Parser) parseParamList(ctx context.Context, defs ...Param) Span {
 
 		p.expect(Describe(ctx, "between keys and values"), ':')
 
-		defs[defsIdx[key]].parse(Describe(ctx, "parameter value"), p)
+		if idx, known := defsIdx[key]; known {
+			defs[idx].parse(Describe(ctx, "parameter value"), p)
+		} else {
+			p.markErr(Note(ctx, "error", "unknown parameter"), keyTok)
+			p.parseValue(Describe(ctx, "parameter value"))
+			EndSpan(ctx, keyTok)
+		}
 
 		if p.peek().Type != ')' {
 			// don't require a trailing comma (but this'll still support it anyway)
@@ -784,7 +797,17 @@ func (p *Parser) parseSubtypeDeclRest(ctx context.Context) ast.SubtypeDecl {
 
 	var unionTag string
 	var unionUntagged bool
+	var structAtomic bool
 	switch declKeyword.Type {
+	case lexer.KWStruct:
+		if p.peek().Type == '(' {
+			atomic := BoolParam{Name: "atomic"}
+			p.parseParamList(Describe(ctx, "struct params"), &atomic)
+
+			if atomic.present() {
+				structAtomic = atomic.Value
+			}
+		}
 	case lexer.KWUnion:
 		unionTag = "type"
 		if p.peek().Type == '(' {
@@ -813,6 +836,7 @@ func (p *Parser) parseSubtypeDeclRest(ctx context.Context) ast.SubtypeDecl {
 			Fields: fields,
 			Subtypes: subtypes,
 			Span: blockSpan,
+			Atomic: structAtomic,
 		}
 	case lexer.KWUnion:
 		// unions parse like structs for now --
@@ -930,6 +954,16 @@ func (p *Parser) parseNewtypeRest(ctx context.Context) ast.Newtype {
 func (p *Parser) parseKindDeclRest(ctx context.Context) ast.KindDecl {
 	ctx = BeginSpan(ctx, p.expect(ctx, lexer.KWKind))
 
+	var nonpersisted bool
+	if p.peek().Type == '(' {
+		nonpersistedParam := BoolParam{Name: "nonpersisted"}
+		p.parseParamList(Describe(ctx, "kind params"), &nonpersistedParam)
+
+		if nonpersistedParam.present() {
+			nonpersisted = nonpersistedParam.Value
+		}
+	}
+
 	name, nameTok := p.expectWithText(Describe(ctx, "kind name"), lexer.TypeIdent)
 	ctx = Note(ctx, "name", name)
 
@@ -939,6 +973,7 @@ func (p *Parser) parseKindDeclRest(ctx context.Context) ast.KindDecl {
 		Name: ast.IdentFrom(name, nameTok),
 		Fields: fields,
 		Subtypes: subtypes,
+		Nonpersisted: nonpersisted,
 		Span: span,
 	}
 }
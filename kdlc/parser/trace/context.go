@@ -286,6 +286,26 @@ func Snippet(loc Span, input string) string {
 	return prefix+"\u300C"+snip+"...\u22EF"
 }
 
+// SpanAt returns the span between two byte offsets in the given input (as
+// recorded in cKDL source maps), positioned in the named file.
+func SpanAt(filename, input string, start, end int) Span {
+	startPos := positionAt(filename, input, start)
+	endPos := positionAt(filename, input, end)
+	return Span{
+		Start: TokenPosition{Start: startPos, End: startPos},
+		End: TokenPosition{Start: endPos, End: endPos},
+	}
+}
+func positionAt(filename, input string, offset int) lexer.Position {
+	lineStart := strings.LastIndexByte(input[:offset], '\n')+1
+	return lexer.Position{
+		Filename: filename,
+		Offset: offset,
+		Line: strings.Count(input[:offset], "\n")+1,
+		Column: offset-lineStart+1,
+	}
+}
+
 func fromSpannable(sp Spannable) Span {
 	if actSpan, isSpan := sp.(Span); isSpan {
 		return actSpan
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package fromast
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+
+	"k8s.io/idl/kdlc/parser/trace"
+	"k8s.io/idl/kdlc/parser/ast"
+	irc "k8s.io/idl/ckdl-ir/goir/constraints"
+)
+
+// builtinMarkers are the markers that may be used without a prefix or
+// import.  Unlike normal markers, these are understood by the compiler
+// itself, and end up in dedicated fields in the IR instead of as attributes.
+var builtinMarkers = map[string]func(context.Context, *ast.AbstractMarker) proto.Message{
+	"cel": celRule,
+}
+
+// celRule converts `@cel(rule: "...", message: "...", field-path: .some.field)`
+// to a validation rule.  The rule itself gets compiled once we have the full
+// type graph, so this just checks the shape of the parameters.
+func celRule(ctx context.Context, raw *ast.AbstractMarker) proto.Message {
+	ctx = ast.In(ctx, raw)
+	res := &irc.Rule{}
+	if raw.Parameters == nil {
+		trace.ErrorAt(ctx, "cel rules need at least a rule and a message")
+		return nil
+	}
+	for _, param := range raw.Parameters.Params {
+		ctx := trace.Note(ast.In(ctx, param), "parameter", param.Key.Name)
+		switch param.Key.Name {
+		case "rule":
+			res.Rule = builtinString(ctx, param.Value)
+		case "message":
+			res.Message = builtinString(ctx, param.Value)
+		case "field-path":
+			path, isPath := param.Value.(ast.FieldPathVal)
+			if !isPath {
+				trace.ErrorAt(ctx, "expected a field path (like `.spec.replicas`)")
+				continue
+			}
+			res.FieldPath = path.Name
+		default:
+			trace.ErrorAt(ctx, "unknown parameter for cel rule, expected `rule`, `message`, or `field-path`")
+		}
+	}
+
+	if res.Rule == "" {
+		trace.ErrorAt(ctx, "cel rules must have a non-empty rule")
+	}
+	if res.Message == "" {
+		trace.ErrorAt(ctx, "cel rules must have a non-empty message")
+	}
+	return res
+}
+
+func builtinString(ctx context.Context, val ast.Value) string {
+	str, isStr := val.(ast.StringVal)
+	if !isStr {
+		trace.ErrorAt(ctx, "expected a string")
+		return ""
+	}
+	return str.Value
+}
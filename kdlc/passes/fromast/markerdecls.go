@@ -137,7 +137,13 @@ func valToProto(ctx context.Context, val ast.Value, typ *irm.Type, emptyVal pr.V
 
 		// TODO(directxman12): support references/sub-values
 	case ast.FieldPathVal:
-		panic("TODO: need a marker field primitive for field paths")
+		fieldPath, isTypePrim := typ.Type.(*irm.Type_TypePrimitive)
+		if !isTypePrim || fieldPath.TypePrimitive != irm.TypePrimitive_FIELD_PATH {
+			trace.ErrorAt(ctx, "mismatched marker parameter value, got a field path")
+			return pr.Value{}
+		}
+		// validity of the path itself is checked once we have the full type graph
+		return pr.ValueOfString(val.Name)
 
 	// TODO(directxman12): support type values
 	case ast.RefTypeVal:
@@ -200,6 +206,11 @@ func (c *markerConverter) makeDescs(ctx context.Context, prefix string, set *ire
 	}
 	for k, v := range res.Definitions {
 		c.defns[k] = v
+		fieldInds := make(map[string]int, len(v.Fields))
+		for i, field := range v.Fields {
+			fieldInds[field.Name] = i
+		}
+		c.fieldsByName[k] = fieldInds
 	}
 	desc, err := pd.NewFile(res.File, nil)
 	if err != nil {
@@ -233,12 +244,17 @@ func (c *markerConverter) getDescriptor(ctx context.Context, rawName mdesc.Marke
 
 func (c *markerConverter) markerToMsg(ctx context.Context, raw *ast.AbstractMarker) proto.Message {
 	nameParts := strings.SplitN(raw.Name.Name, "::", 2)
-	name := mdesc.MarkerIdent{}
 	if len(nameParts) == 1 {
-		name.Name = nameParts[0]
-	} else {
-		name.Prefix = nameParts[0]
-		name.Name = nameParts[1]
+		builtin, known := builtinMarkers[nameParts[0]]
+		if !known {
+			trace.ErrorAt(ast.In(ctx, raw), "unknown built-in marker (did you forget a prefix?)")
+			return nil
+		}
+		return builtin(ctx, raw)
+	}
+	name := mdesc.MarkerIdent{
+		Prefix: nameParts[0],
+		Name: nameParts[1],
 	}
 
 	desc := c.getDescriptor(ctx, name)
@@ -251,7 +267,15 @@ func (c *markerConverter) markerToMsg(ctx context.Context, raw *ast.AbstractMark
 	msg := dynamicpb.NewMessage(desc)
 	defFields := desc.Fields()
 
-	for _, param := range raw.Parameters.Params {
+	// markers without parameters (e.g. `@crd::storage-version`) have no
+	// parameter block, but still need any required fields checked below
+	var params []ast.KeyValue
+	if raw.Parameters != nil {
+		params = raw.Parameters.Params
+	}
+
+	seen := make(map[string]bool, len(params))
+	for _, param := range params {
 		paramName := strings.Replace(param.Key.Name, "-", "_", -1)
 		fieldDef := defFields.ByName(pr.Name(paramName))
 		if fieldDef == nil {
@@ -259,6 +283,7 @@ func (c *markerConverter) markerToMsg(ctx context.Context, raw *ast.AbstractMark
 			trace.ErrorAt(ctx, "unknown parameter in marker")
 			continue
 		}
+		seen[param.Key.Name] = true
 		field := def.Fields[fieldInds[param.Key.Name]]
 		val := valToProto(ctx, param.Value, field.Type, msg.NewField(fieldDef))
 		if !val.IsValid() {
@@ -267,6 +292,13 @@ func (c *markerConverter) markerToMsg(ctx context.Context, raw *ast.AbstractMark
 		msg.Set(fieldDef, val)
 	}
 
+	for _, field := range def.Fields {
+		if field.Optional || field.Default != nil || seen[field.Name] {
+			continue
+		}
+		trace.ErrorAt(trace.Note(ast.In(trace.Describe(ctx, "marker"), raw), "parameter", field.Name), "missing required parameter in marker")
+	}
+
 	return msg
 }
 
@@ -280,8 +312,9 @@ type Requester interface {
 }
 
 func ResolveMarkers(ctx context.Context, file *ast.File, req Requester) {
+	// NB: built-in markers have no prefix, so they don't need to
+	// be registered here (see builtinMarkers)
 	prefixes := make(map[string]string)
-	// TODO: inject the built-in markers
 	if file.Imports != nil && file.Imports.Markers != nil {
 		for _, src := range file.Imports.Markers.Imports {
 			prefixes[src.Alias] = src.Src
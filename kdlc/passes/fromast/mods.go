@@ -5,11 +5,13 @@ package fromast
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"k8s.io/idl/kdlc/parser/trace"
 	"k8s.io/idl/kdlc/parser/ast"
 	ir "k8s.io/idl/ckdl-ir/goir/types"
 	irc "k8s.io/idl/ckdl-ir/goir/constraints"
+	irm "k8s.io/idl/ckdl-ir/goir/markers"
 )
 
 // TODO(directxman12): we could make all of this easier to expand & cleaner with reflection,
@@ -203,17 +205,24 @@ func updateTypeInfo(ctx context.Context, info *ast.ResolvedTypeInfo, mod ast.Mod
 					// default is just name
 					res.KeyField = append(res.KeyField, "name")
 				case ast.ListVal:
-					keysCtx := trace.InSpan(trace.Describe(ctx, "value"), params["value"].Value)
+					keysCtx := trace.InSpan(trace.Describe(ctx, "keys"), params["keys"].Value)
 					for _, keyRaw := range keys.Values {
+						keyCtx := trace.InSpan(trace.Describe(keysCtx, "key"), keyRaw)
 						key, isPath := keyRaw.(ast.FieldPathVal)
 						if !isPath {
-							keyCtx := trace.InSpan(trace.Describe(keysCtx, "key"), keyRaw)
 							trace.ErrorAt(keyCtx, "invalid key, expected a field path")
+							continue
+						}
+						if strings.Contains(key.Name, ".") {
+							// list-map keys name fields of the items
+							// themselves, not nested fields
+							trace.ErrorAt(keyCtx, "invalid key, expected a single field (like .name), not a nested path")
+							continue
 						}
 						res.KeyField = append(res.KeyField, key.Name)
 					}
 				default:
-					keysCtx := trace.InSpan(trace.Describe(ctx, "value"), params["value"].Value)
+					keysCtx := trace.InSpan(trace.Describe(ctx, "keys"), params["keys"].Value)
 					trace.ErrorAt(keysCtx, "invalid keys for list, expected a list of field paths")
 				}
 			}
@@ -244,8 +253,11 @@ func updateTypeInfo(ctx context.Context, info *ast.ResolvedTypeInfo, mod ast.Mod
 			setTypeFrom(ctx, info, mod, ast.SetType(res))
 		case "simple-map":
 			ctx = trace.Note(ctx, "name", "simple-map")
-			params := validParameters(ctx, mod.Parameters, []string{"value"}, []string{"key"})
+			params := validParameters(ctx, mod.Parameters, []string{"value"}, []string{"key", "atomic"})
 			res := ir.PrimitiveMap{}
+			if atomic := params["atomic"]; atomic != nil {
+				res.Atomic = assertBool(trace.Describe(ctx, "atomic"), atomic.Value)
+			}
 
 			switch val := params["value"].Value.(type) {
 			case ast.PrimitiveTypeVal:
@@ -303,6 +315,11 @@ func updateTypeInfo(ctx context.Context, info *ast.ResolvedTypeInfo, mod ast.Mod
 			}
 			setTypeFrom(ctx, info, mod, ast.PrimitiveMapType(res))
 
+		// marker-only types
+		case "field-path":
+			ctx = trace.Note(ctx, "name", "field-path")
+			setTypeFrom(ctx, info, mod, ast.MarkerPrimitiveType(irm.TypePrimitive_FIELD_PATH))
+
 		// misc modifiers
 		case "optional":
 			ctx = trace.Note(ctx, "name", "optional")
@@ -360,8 +377,10 @@ func refModToRef(mod ast.RefModifier) ir.Reference {
 		Name: mod.Name.Name,
 	}
 	if gv := mod.GroupVersion; gv != nil {
-		ref.GroupVersion.Group = gv.Group
-		ref.GroupVersion.Version = gv.Version
+		ref.GroupVersion = &ir.GroupVersionRef{
+			Group: gv.Group,
+			Version: gv.Version,
+		}
 	}
 	return ref
 }
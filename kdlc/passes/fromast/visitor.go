@@ -15,11 +15,11 @@ type MarkerVisitor interface {
 func VisitMarkers(ctx context.Context, v MarkerVisitor, gv *ast.GroupVersion) {
 	gvCtx := trace.Describe(ctx, "group-version")
 	gvCtx = trace.Note(gvCtx, "group", gv.Group)
-	gvCtx = trace.Note(gvCtx, "version", gv.Group)
+	gvCtx = trace.Note(gvCtx, "version", gv.Version)
 	gvCtx = trace.InSpan(gvCtx, gv)
 
-	for _, marker := range gv.Markers {
-		v.VisitMarker(gvCtx, &marker)
+	for i := range gv.Markers {
+		v.VisitMarker(gvCtx, &gv.Markers[i])
 	}
 
 	VisitGroupVersion(ctx, markerVisitor{v}, gv)
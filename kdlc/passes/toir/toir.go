@@ -76,6 +76,19 @@ func (m *Mapper) Item(ind int) *Mapper {
 		current: m.current,
 	}
 }
+// MarkerParam maps a parameter of the marker encoded in the attribute at
+// this position.  The marker's message is packed into the attribute's
+// value, so the last path element is a field number of the marker's
+// message, not of Any.
+func (m *Mapper) MarkerParam(field protoreflect.FieldDescriptor) *Mapper {
+	value := m.Field("value")
+	return &Mapper{
+		srcMap: m.srcMap,
+		loc: value.partialLoc(int32(field.Number())),
+		current: field.Message(),
+	}
+}
+
 func (m *Mapper) From(span trace.Spannable) *Mapper {
 	start, end := span.SpanStart(), span.SpanEnd()
 	m.loc.Span = []int32{int32(start.Start.Offset), int32(end.End.Offset)}
@@ -145,17 +158,48 @@ func Docs(ctx context.Context, m *Mapper, docs ast.Docs) *irt.Documentation {
 	return &res
 }
 func Markers(ctx context.Context, m *Mapper, markers []ast.AbstractMarker) []*any.Any {
-	res := make([]*any.Any, len(markers))
-	for i, raw := range markers {
-		m.Item(i).From(raw)
+	attrs, _ := MarkersAndRules(ctx, m, nil, markers)
+	return attrs
+}
+
+// MarkersAndRules is like Markers, except that it splits out validation
+// rules (`@cel(...)`) into their own list.  If rulesM is nil, rules aren't
+// allowed on the target, and any that are present are reported as errors.
+func MarkersAndRules(ctx context.Context, attrM, rulesM *Mapper, markers []ast.AbstractMarker) ([]*any.Any, []*irc.Rule) {
+	var attrs []*any.Any
+	var rules []*irc.Rule
+	for _, raw := range markers {
+		if rule, isRule := raw.Resolved.Message.(*irc.Rule); isRule {
+			if rulesM == nil {
+				trace.ErrorAt(ast.In(ctx, raw), "cel rules may only be attached to kinds, structs, unions, and fields")
+				continue
+			}
+			rulesM.Item(len(rules)).From(raw)
+			rules = append(rules, rule)
+			continue
+		}
+
+		itemM := attrM.Item(len(attrs)).From(raw)
+		if raw.Parameters != nil && raw.Resolved.Message != nil {
+			// so that later checks on parameters (e.g. field paths) can
+			// point at them
+			fields := raw.Resolved.Message.ProtoReflect().Descriptor().Fields()
+			for _, param := range raw.Parameters.Params {
+				field := fields.ByName(protoreflect.Name(strings.Replace(param.Key.Name, "-", "_", -1)))
+				if field == nil || param.Value == nil {
+					continue
+				}
+				itemM.MarkerParam(field).From(param.Value)
+			}
+		}
 		enc, err := any.New(raw.Resolved.Message)
 		if err != nil {
 			trace.ErrorAt(trace.Note(ast.In(ctx, raw), "error", err), "unable to store encoded marker")
 			continue
 		}
-		res[i] = enc
+		attrs = append(attrs, enc)
 	}
-	return res
+	return attrs, rules
 }
 
 func GroupVersion(ctx context.Context, m *Mapper, gv ast.GroupVersion) *ire.GroupVersion {
@@ -196,10 +240,10 @@ func Kind(ctx context.Context, m *Mapper, typesM *Mapper, gv *ire.GroupVersion,
 
 	res := irt.Kind{
 		Name: kind.Name.Name,
-		Object: true,  // TODO
+		Object: !kind.Nonpersisted,
 		Docs: Docs(ctx, m.Field("docs"), kind.Docs),
-		Attributes: Markers(ctx, m.Field("attributes"), kind.Markers),
 	}
+	res.Attributes, res.Rules = MarkersAndRules(ctx, m.Field("attributes"), m.Field("rules"), kind.Markers)
 	fieldM := m.Field("fields")
 	for i, field := range kind.Fields {
 		res.Fields = append(res.Fields, Field(ctx, fieldM.Item(i), field))
@@ -314,13 +358,27 @@ func Subtype(ctx context.Context, typesM *Mapper, gv *ire.GroupVersion, subtype
 	res := &irt.Subtype{
 		Name: subtype.ResolvedName.FullName,
 		Docs: Docs(ctx, m.Field("docs"), subtype.Docs),
-		Attributes: Markers(ctx, m.Field("attributes"), subtype.Markers),
 	}
 	gv.Types = append(gv.Types, res) // keep our spot like we recorded above
+
+	// rules live on the struct/union bodies, since other subtypes can't have them
+	var rulesM *Mapper
+	switch subtype.Body.(type) {
+	case *ast.Struct:
+		rulesM = m.Field("struct").Field("rules")
+	case *ast.Union:
+		rulesM = m.Field("union").Field("rules")
+	}
+	attrs, rules := MarkersAndRules(ctx, m.Field("attributes"), rulesM, subtype.Markers)
+	res.Attributes = attrs
+
 	switch body := subtype.Body.(type) {
 	case *ast.Struct:
 		m := m.Field("struct").From(body)
-		strct := irt.Struct{}
+		strct := irt.Struct{
+			Atomic: body.Atomic,
+			Rules: rules,
+		}
 		// TODO: figure out how to populate preserve-unknown-fields &
 		// embedded-object here (maybe parameters on struct() in the idl?)
 		fieldM := m.Field("fields")
@@ -337,6 +395,7 @@ func Subtype(ctx context.Context, typesM *Mapper, gv *ire.GroupVersion, subtype
 		union := irt.Union{
 			Tag: body.Tag,
 			Untagged: body.Untagged,
+			Rules: rules,
 		}
 		fieldM := m.Field("variants")
 		for i, field := range body.Variants {
@@ -409,6 +468,8 @@ func Subtype(ctx context.Context, typesM *Mapper, gv *ire.GroupVersion, subtype
 				act.ObjectConstraints = body.ResolvedType.Validates.Objectish
 			}
 			res.Type = &irt.Subtype_PrimitiveMap{PrimitiveMap: &act}
+		case ast.MarkerPrimitiveType:
+			trace.ErrorAt(ctx, "field-path may only be used in marker definitions")
 		default:
 			panic("unreachable: unknown newtype")
 		}
@@ -422,12 +483,13 @@ func Field(ctx context.Context, m *Mapper, field ast.Field) *irt.Field {
 	res := irt.Field{
 		Name: name,
 		Optional: field.ResolvedType.Optional,
+		CreateOnly: field.ResolvedType.CreateOnly,
 		Embedded: name == "", // TODO
 		// TODO: zero-means-absent
 		Docs: Docs(ctx, m.Field("docs"), field.Docs),
-		Attributes: Markers(ctx, m.Field("attributes"), field.Markers),
 		ProtoTag: field.ProtoTag,
 	}
+	res.Attributes, res.Rules = MarkersAndRules(ctx, m.Field("attributes"), m.Field("rules"), field.Markers)
 
 	// TODO: mapping for default (should values get full map entries?)
 	if field.ResolvedType.Default != nil {
@@ -479,6 +541,8 @@ func Field(ctx context.Context, m *Mapper, field ast.Field) *irt.Field {
 			act.ObjectConstraints = field.ResolvedType.Validates.Objectish
 		}
 		res.Type = &irt.Field_PrimitiveMap{PrimitiveMap: &act}
+	case ast.MarkerPrimitiveType:
+		trace.ErrorAt(ctx, "field-path may only be used in marker definitions")
 	default:
 		panic("unreachable: unknown newtype")
 	}
@@ -518,7 +582,7 @@ func Value(ctx context.Context, value ast.Value) *pstruct.Value {
 			},
 		}
 	case ast.StructVal:
-		var vals map[string]*pstruct.Value
+		vals := make(map[string]*pstruct.Value, len(value.KeyValues))
 		for _, item := range value.KeyValues {
 			vals[item.Key.Name] = Value(ctx, item.Value)
 		}
@@ -602,6 +666,9 @@ func MarkerField(ctx context.Context, m *Mapper, field ast.Field) *irm.MarkerFie
 			act.ListConstraints = field.ResolvedType.Validates.List
 		}
 		res.Type = &irm.Type{Type: &irm.Type_List{List: &act}}
+	case ast.MarkerPrimitiveType:
+		m.Field("type_primitive").From(field.ResolvedType.TypeSrc)
+		res.Type = &irm.Type{Type: &irm.Type_TypePrimitive{TypePrimitive: irm.TypePrimitive(typ)}}
 	case ast.RefType:
 		panic("TODO: references in markers" )
 	// TODO: typecheck marker fields (no set, list map, special map support, etc)
@@ -0,0 +1,297 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package typecheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"google.golang.org/protobuf/proto"
+
+	irt "k8s.io/idl/ckdl-ir/goir/types"
+	irc "k8s.io/idl/ckdl-ir/goir/constraints"
+	"k8s.io/idl/kdlc/parser/trace"
+	"k8s.io/idl/backends/common/celname"
+)
+
+// celTypes exposes the type graph to the CEL type-checker, so that rules
+// can be checked against the shape of the values they'll actually see.
+//
+// The types mirror what the API server derives from the generated OpenAPI
+// schemata (e.g. times are timestamps, int-or-string is dynamic), so a rule
+// that compiles here should also compile once it's in a CRD.
+type celTypes struct {
+	ref.TypeProvider
+
+	ctx context.Context
+	g Graphish
+
+	// objects maps CEL object type names to the types that have fields
+	objects map[string]Terminal
+}
+
+func newCELTypes(ctx context.Context, g Graphish) *celTypes {
+	return &celTypes{
+		// handles the well-known types
+		TypeProvider: types.NewEmptyRegistry(),
+		ctx: ctx,
+		g: g,
+		objects: make(map[string]Terminal),
+	}
+}
+
+// object records a type with fields, returning its CEL type.
+func (t *celTypes) object(name Name, term Terminal) *exprpb.Type {
+	typeName := name.String()
+	t.objects[typeName] = term
+	return decls.NewObjectType(typeName)
+}
+
+func (t *celTypes) FindType(typeName string) (*exprpb.Type, bool) {
+	if _, known := t.objects[typeName]; known {
+		return decls.NewTypeType(decls.NewObjectType(typeName)), true
+	}
+	return t.TypeProvider.FindType(typeName)
+}
+
+func (t *celTypes) FindFieldType(typeName, fieldName string) (*ref.FieldType, bool) {
+	term, known := t.objects[typeName]
+	if !known {
+		return t.TypeProvider.FindFieldType(typeName, fieldName)
+	}
+	fieldName = celname.Unescape(fieldName)
+
+	switch term := term.(type) {
+	case TerminalKind:
+		switch fieldName {
+		case "apiVersion", "kind":
+			return &ref.FieldType{Type: decls.String}, true
+		case "metadata":
+			// TODO: the API server exposes name & generateName here
+			return &ref.FieldType{Type: decls.Dyn}, true
+		}
+	case TerminalUnion:
+		if !term.Union.Untagged && fieldName == term.Union.Tag {
+			return &ref.FieldType{Type: decls.String}, true
+		}
+	}
+
+	field := findField(t.ctx, t.g, fieldsOf(term), fieldName)
+	if field == nil {
+		return nil, false
+	}
+	return &ref.FieldType{Type: t.fieldType(field)}, true
+}
+
+func (t *celTypes) fieldType(field *irt.Field) *exprpb.Type {
+	switch typ := field.Type.(type) {
+	case *irt.Field_Primitive:
+		return celPrimitive(typ.Primitive)
+	case *irt.Field_NamedType:
+		return t.refType(typ.NamedType)
+	case *irt.Field_Set:
+		return t.setType(typ.Set)
+	case *irt.Field_List:
+		return t.listType(typ.List)
+	case *irt.Field_PrimitiveMap:
+		return t.mapType(typ.PrimitiveMap)
+	case *irt.Field_ListMap:
+		return decls.NewListType(t.refType(typ.ListMap.Items))
+	default:
+		panic(fmt.Sprintf("unreachable: unknown field type %T", typ))
+	}
+}
+
+func (t *celTypes) refType(ref *irt.Reference) *exprpb.Type {
+	name := NameFromRef(ref)
+	switch term := t.g.TerminalFor(t.ctx, name).(type) {
+	case TerminalKind, TerminalStruct, TerminalUnion:
+		return t.object(name, term)
+	case TerminalEnum:
+		return decls.String
+	case TerminalWrapper:
+		return t.wrapperType(term.Wrapper)
+	default:
+		// already reported by TerminalFor
+		return decls.Dyn
+	}
+}
+
+func (t *celTypes) wrapperType(subtype *irt.Subtype) *exprpb.Type {
+	switch typ := subtype.Type.(type) {
+	case *irt.Subtype_PrimitiveAlias:
+		return celPrimitive(typ.PrimitiveAlias)
+	case *irt.Subtype_ReferenceAlias:
+		return t.refType(typ.ReferenceAlias)
+	case *irt.Subtype_Set:
+		return t.setType(typ.Set)
+	case *irt.Subtype_List:
+		return t.listType(typ.List)
+	case *irt.Subtype_PrimitiveMap:
+		return t.mapType(typ.PrimitiveMap)
+	case *irt.Subtype_ListMap:
+		return decls.NewListType(t.refType(typ.ListMap.Items))
+	default:
+		panic(fmt.Sprintf("unreachable: unknown wrapper type %T", typ))
+	}
+}
+
+func (t *celTypes) setType(set *irt.Set) *exprpb.Type {
+	switch items := set.Items.(type) {
+	case *irt.Set_Primitive:
+		return decls.NewListType(celPrimitive(items.Primitive))
+	case *irt.Set_Reference:
+		return decls.NewListType(t.refType(items.Reference))
+	default:
+		panic(fmt.Sprintf("unreachable: unknown set item type %T", items))
+	}
+}
+
+func (t *celTypes) listType(list *irt.List) *exprpb.Type {
+	switch items := list.Items.(type) {
+	case *irt.List_Primitive:
+		return decls.NewListType(celPrimitive(items.Primitive))
+	case *irt.List_Reference:
+		return decls.NewListType(t.refType(items.Reference))
+	default:
+		panic(fmt.Sprintf("unreachable: unknown list item type %T", items))
+	}
+}
+
+func (t *celTypes) mapType(primMap *irt.PrimitiveMap) *exprpb.Type {
+	// keys are always strings once serialized
+	switch val := primMap.Value.(type) {
+	case *irt.PrimitiveMap_PrimitiveValue:
+		return decls.NewMapType(decls.String, celPrimitive(val.PrimitiveValue))
+	case *irt.PrimitiveMap_ReferenceValue:
+		return decls.NewMapType(decls.String, t.refType(val.ReferenceValue))
+	case *irt.PrimitiveMap_SimpleListValue:
+		return decls.NewMapType(decls.String, t.listType(val.SimpleListValue))
+	default:
+		panic(fmt.Sprintf("unreachable: unknown map value type %T", val))
+	}
+}
+
+func celPrimitive(prim *irt.Primitive) *exprpb.Type {
+	switch prim.Type {
+	case irt.Primitive_STRING, irt.Primitive_DURATION:
+		// durations don't get a format in the schema, so they're just strings
+		return decls.String
+	case irt.Primitive_LEGACYINT32, irt.Primitive_INT64:
+		return decls.Int
+	case irt.Primitive_BOOL:
+		return decls.Bool
+	case irt.Primitive_TIME:
+		return decls.Timestamp
+	case irt.Primitive_BYTES:
+		return decls.Bytes
+	case irt.Primitive_LEGACYFLOAT64:
+		return decls.Double
+	case irt.Primitive_QUANTITY, irt.Primitive_INTORSTRING:
+		// int-or-string could be either, so it's dynamic
+		return decls.Dyn
+	default:
+		panic(fmt.Sprintf("unreachable: unknown primitive type %v", prim.Type))
+	}
+}
+
+// CheckRules compiles each CEL validation rule in the graph against the type
+// of the thing it's attached to, making sure that it evaluates to a boolean,
+// and that its field path (if any) exists.
+func (g *Graph) CheckRules(ctx context.Context) {
+	for _, node := range g.PathToNode {
+		for _, irGV := range node.Partial.GroupVersions {
+			gv := GVFromDesc(irGV.Description)
+			for _, kind := range irGV.Kinds {
+				name := gv.WithName(kind.Name)
+				ctx := trace.Note(trace.Describe(ctx, "kind"), "name", name)
+				term := TerminalKind{kind}
+				checkRules(ctx, g, kind.Rules, func(t *celTypes) *exprpb.Type { return t.object(name, term) }, term)
+				g.checkFieldRules(ctx, kind.Fields)
+			}
+			for _, subtype := range irGV.Types {
+				name := gv.WithName(subtype.Name)
+				ctx := trace.Note(trace.Describe(ctx, "subtype"), "name", name)
+				switch typ := subtype.Type.(type) {
+				case *irt.Subtype_Struct:
+					term := TerminalStruct{typ.Struct}
+					checkRules(ctx, g, typ.Struct.Rules, func(t *celTypes) *exprpb.Type { return t.object(name, term) }, term)
+					g.checkFieldRules(ctx, typ.Struct.Fields)
+				case *irt.Subtype_Union:
+					term := TerminalUnion{typ.Union}
+					checkRules(ctx, g, typ.Union.Rules, func(t *celTypes) *exprpb.Type { return t.object(name, term) }, term)
+					g.checkFieldRules(ctx, typ.Union.Variants)
+				// no other types can have rules
+				}
+			}
+		}
+	}
+}
+
+func (g *Graph) checkFieldRules(ctx context.Context, fields []*irt.Field) {
+	for _, field := range fields {
+		if len(field.Rules) == 0 {
+			continue
+		}
+		ctx := trace.Note(trace.Describe(ctx, "field"), "name", field.Name)
+
+		// field paths are relative to the field's value, so they
+		// only make sense if the field is of a type with fields
+		var pathTarget Terminal
+		if ref, isRef := field.Type.(*irt.Field_NamedType); isRef {
+			pathTarget = g.TerminalFor(ctx, NameFromRef(ref.NamedType))
+		}
+		checkRules(ctx, g, field.Rules, func(t *celTypes) *exprpb.Type { return t.fieldType(field) }, pathTarget)
+	}
+}
+
+// checkRules checks a set of rules that all apply to the same value.  selfType
+// computes the type of that value, and pathTarget is the type that field
+// paths are relative to (which may be nil if the value has no fields).
+func checkRules(ctx context.Context, g Graphish, rules []*irc.Rule, selfType func(*celTypes) *exprpb.Type, pathTarget Terminal) {
+	if len(rules) == 0 {
+		return
+	}
+
+	provider := newCELTypes(ctx, g)
+	self := selfType(provider)
+	env, err := cel.NewEnv(
+		cel.CustomTypeProvider(provider),
+		cel.Declarations(
+			decls.NewVar("self", self),
+			// only meaningful in transition rules, but the API
+			// server figures out which rules those are for us
+			decls.NewVar("oldSelf", self),
+		),
+	)
+	if err != nil {
+		trace.ErrorAt(trace.Note(ctx, "error", err), "unable to set up environment for cel rules")
+		return
+	}
+
+	for _, rule := range rules {
+		ctx := trace.Note(trace.Describe(ctx, "cel rule"), "rule", rule.Rule)
+		ast, issues := env.Compile(rule.Rule)
+		if issues != nil && issues.Err() != nil {
+			trace.ErrorAt(trace.Note(ctx, "issues", issues.String()), "invalid cel rule")
+			continue
+		}
+		if resType := ast.ResultType(); !proto.Equal(resType, decls.Bool) && !proto.Equal(resType, decls.Dyn) {
+			trace.ErrorAt(ctx, "cel rules must evaluate to a boolean")
+		}
+
+		if rule.FieldPath == "" {
+			continue
+		}
+		if pathTarget == nil {
+			trace.ErrorAt(ctx, "field paths may only be used in rules on types with fields")
+			continue
+		}
+		checkFieldPath(ctx, g, pathTarget, rule.FieldPath)
+	}
+}
@@ -4,8 +4,12 @@ package typecheck
 
 import (
 	"context"
+	"strings"
+
+	pr "google.golang.org/protobuf/reflect/protoreflect"
 
 	irt "k8s.io/idl/ckdl-ir/goir/types"
+	irm "k8s.io/idl/ckdl-ir/goir/markers"
 	"k8s.io/idl/kdlc/parser/trace"
 )
 
@@ -13,7 +17,6 @@ import (
 // validation (literally do we have the right fields), these handle cases
 // that require graph info, like references
 
-// TODO: any marker checking whatsoever
 
 func CheckFieldValidation(ctx context.Context, g Graphish, field *irt.Field, source interface{}) {
 	panic("TODO")
@@ -77,6 +80,102 @@ func CheckReferences(ctx context.Context, g Graphish, ref *irt.Reference) {
 	g.TerminalFor(ctx, NameFromRef(ref))
 }
 
+// CheckMarkerFieldPaths checks that field-path parameters of markers refer to
+// fields that actually exist, relative to the type the marker is attached to.
+func CheckMarkerFieldPaths(ctx context.Context, g Graphish, marker KnownMarker, msg pr.Message, target Terminal, src MarkerSource) {
+	fieldDescs := marker.Descriptor.Fields()
+	for _, fieldDef := range marker.Definition.Fields {
+		fieldDesc := fieldDescs.ByName(pr.Name(strings.Replace(fieldDef.Name, "-", "_", -1)))
+		if fieldDesc == nil || !msg.Has(fieldDesc) {
+			continue
+		}
+		ctx := trace.Note(src.InParam(trace.Describe(ctx, "parameter"), fieldDesc), "name", fieldDef.Name)
+
+		switch typ := fieldDef.Type.Type.(type) {
+		case *irm.Type_TypePrimitive:
+			if typ.TypePrimitive != irm.TypePrimitive_FIELD_PATH {
+				continue
+			}
+			checkFieldPath(ctx, g, target, msg.Get(fieldDesc).String())
+		case *irm.Type_List:
+			items, isTypePrim := typ.List.Items.Type.(*irm.Type_TypePrimitive)
+			if !isTypePrim || items.TypePrimitive != irm.TypePrimitive_FIELD_PATH {
+				continue
+			}
+			paths := msg.Get(fieldDesc).List()
+			for i := 0; i < paths.Len(); i++ {
+				checkFieldPath(ctx, g, target, paths.Get(i).String())
+			}
+		}
+	}
+}
+
+func checkFieldPath(ctx context.Context, g Graphish, target Terminal, path string) {
+	ctx = trace.Note(ctx, "field path", "."+path)
+
+	fields := fieldsOf(target)
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		ctx := trace.Note(ctx, "segment", segment)
+		if fields == nil {
+			trace.ErrorAt(ctx, "field path goes through a type that has no fields")
+			return
+		}
+		field := findField(ctx, g, fields, segment)
+		if field == nil {
+			trace.ErrorAt(ctx, "field path refers to a field that does not exist")
+			return
+		}
+		if i == len(segments)-1 {
+			return
+		}
+
+		ref, isRef := field.Type.(*irt.Field_NamedType)
+		if !isRef {
+			trace.ErrorAt(ctx, "field path goes through a field that isn't a struct")
+			return
+		}
+		fields = fieldsOf(g.TerminalFor(ctx, NameFromRef(ref.NamedType)))
+	}
+}
+
+// fieldsOf returns the fields of the given terminal, or nil if it has none.
+func fieldsOf(term Terminal) []*irt.Field {
+	switch term := term.(type) {
+	case TerminalKind:
+		return term.Kind.Fields
+	case TerminalStruct:
+		return term.Struct.Fields
+	case TerminalUnion:
+		return term.Union.Variants
+	default:
+		return nil
+	}
+}
+
+// findField finds the field with the given name, looking through
+// embedded fields as necessary.
+func findField(ctx context.Context, g Graphish, fields []*irt.Field, name string) *irt.Field {
+	for _, field := range fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	for _, field := range fields {
+		if !field.Embedded {
+			continue
+		}
+		ref, isRef := field.Type.(*irt.Field_NamedType)
+		if !isRef {
+			continue
+		}
+		if found := findField(ctx, g, fieldsOf(g.TerminalFor(ctx, NameFromRef(ref.NamedType))), name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
 // TODO: start from roots, only check things that matter?
 
 func CheckAll(ctx context.Context, g *Graph) {
@@ -88,5 +187,11 @@ func CheckAll(ctx context.Context, g *Graph) {
 	g.CheckReferences(ctx, CheckReferences)
 	g.CheckFields(ctx, CheckFieldType)
 	g.CheckSubtypes(ctx, CheckWrapperType)
+	g.CheckMarkers(ctx, CheckMarkerFieldPaths)
+	if trace.HadError(ctx) {
+		// rules need a sane type graph to compile against
+		return
+	}
+	g.CheckRules(ctx)
 	// TODO: rest aren't implemented
 }
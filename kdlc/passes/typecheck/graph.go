@@ -6,6 +6,8 @@ import (
 	"context"
 	"fmt"
 
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+
 	irt "k8s.io/idl/ckdl-ir/goir/types"
 	irgv "k8s.io/idl/ckdl-ir/goir/groupver"
 	ire "k8s.io/idl/ckdl-ir/goir"
@@ -42,6 +44,10 @@ type Name struct {
 	FullName string
 }
 
+func (n Name) String() string {
+	return fmt.Sprintf("%s/%s::%s", n.Group, n.Version, n.FullName)
+}
+
 func NameFromRef(ref *irt.Reference) Name {
 	return GVFromRef(ref.GroupVersion).WithName(ref.Name)
 }
@@ -144,6 +150,16 @@ type Graph struct {
 	// GVToNode maps a group-version to the virtual (marged) node that corresponds
 	// to it.  This could involve multiple nodes.
 	GVToNode map[GroupVersion]*MergedNode
+
+	// Markers maps the proto names of known markers to their definitions.
+	Markers map[pr.FullName]KnownMarker
+	// markerSrcs tracks which files we've already loaded markers from.
+	markerSrcs map[string]bool
+
+	// Sources are the contents of the files compiled from KDL source, by
+	// import path, for pointing errors at the KDL responsible (files
+	// loaded from cKDL have no source to point at).
+	Sources map[string][]byte
 }
 
 func NewGraph(imports Requester) *Graph {
@@ -151,6 +167,8 @@ func NewGraph(imports Requester) *Graph {
 		Imports: imports,
 		PathToNode: make(map[string]*Node),
 		GVToNode: make(map[GroupVersion]*MergedNode),
+		Markers: make(map[pr.FullName]KnownMarker),
+		markerSrcs: make(map[string]bool),
 	}
 }
 
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package typecheck
+
+import (
+	"context"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/any"
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+	pd "google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	irt "k8s.io/idl/ckdl-ir/goir/types"
+	irm "k8s.io/idl/ckdl-ir/goir/markers"
+	ire "k8s.io/idl/ckdl-ir/goir"
+	"k8s.io/idl/kdlc/mdesc"
+	"k8s.io/idl/kdlc/parser/trace"
+)
+
+// KnownMarker is a marker definition, plus the proto descriptor that
+// instances of it are encoded with.
+type KnownMarker struct {
+	Definition *irm.MarkerDef
+	Descriptor pr.MessageDescriptor
+}
+
+// AddMarkerSets records the marker definitions from the given file, so that
+// marker instances elsewhere in the graph can be checked against them.
+func (g *Graph) AddMarkerSets(ctx context.Context, path string, sets []*ire.MarkerSet) {
+	if g.markerSrcs[path] {
+		return
+	}
+	g.markerSrcs[path] = true
+
+	ctx = trace.Note(trace.Describe(ctx, "marker definitions"), "path", path)
+	for _, set := range sets {
+		res := mdesc.MakeDescriptor(ctx, path, set)
+		file, err := pd.NewFile(res.File, nil)
+		if err != nil {
+			trace.ErrorAt(trace.Note(ctx, "error", err), "unable to compile markers to proto")
+			continue
+		}
+		for ident, descName := range res.DescriptorNames {
+			desc := file.Messages().ByName(pr.Name(descName.Name))
+			if desc == nil {
+				continue
+			}
+			g.Markers[desc.FullName()] = KnownMarker{
+				Definition: res.Definitions[ident],
+				Descriptor: desc,
+			}
+		}
+	}
+}
+
+// CheckMarkers calls the given check on each known marker attached to a kind,
+// struct, or union in the graph.  Markers whose definitions weren't loaded
+// (e.g. markers for backends that don't participate in this compile) are
+// skipped.
+func (g *Graph) CheckMarkers(ctx context.Context, check MarkerCheck) {
+	for _, node := range g.PathToNode {
+		gvsPath := []int32{fieldNumber(node.Partial, "group_versions")}
+		for i, irGV := range node.Partial.GroupVersions {
+			gv := GVFromDesc(irGV.Description)
+			gvPath := appendPath(gvsPath, int32(i))
+			for j, kind := range irGV.Kinds {
+				ctx := trace.Note(trace.Describe(ctx, "kind"), "name", gv.WithName(kind.Name))
+				attrsPath := appendPath(gvPath, fieldNumber(irGV, "kinds"), int32(j), fieldNumber(kind, "attributes"))
+				g.checkAttributes(ctx, node, attrsPath, kind.Attributes, TerminalKind{kind}, check)
+			}
+			for j, subtype := range irGV.Types {
+				ctx := trace.Note(trace.Describe(ctx, "subtype"), "name", gv.WithName(subtype.Name))
+				attrsPath := appendPath(gvPath, fieldNumber(irGV, "types"), int32(j), fieldNumber(subtype, "attributes"))
+				switch typ := subtype.Type.(type) {
+				case *irt.Subtype_Struct:
+					g.checkAttributes(ctx, node, attrsPath, subtype.Attributes, TerminalStruct{typ.Struct}, check)
+				case *irt.Subtype_Union:
+					g.checkAttributes(ctx, node, attrsPath, subtype.Attributes, TerminalUnion{typ.Union}, check)
+				// markers on other types have no fields to refer to
+				}
+			}
+		}
+	}
+}
+
+func (g *Graph) checkAttributes(ctx context.Context, node *Node, attrsPath []int32, attrs []*any.Any, target Terminal, check MarkerCheck) {
+	for i, attr := range attrs {
+		name := pr.FullName(attr.TypeUrl[strings.LastIndexByte(attr.TypeUrl, '/')+1:])
+		known, isKnown := g.Markers[name]
+		if !isKnown {
+			continue
+		}
+		src := MarkerSource{g: g, node: node, path: appendPath(attrsPath, int32(i))}
+		ctx := trace.Note(src.In(trace.Describe(ctx, "marker")), "name", string(name))
+		msg := dynamicpb.NewMessage(known.Descriptor)
+		if err := proto.Unmarshal(attr.Value, msg); err != nil {
+			trace.ErrorAt(trace.Note(ctx, "error", err), "unable to decode marker")
+			continue
+		}
+		check(ctx, g, known, msg, target, src)
+	}
+}
+
+type MarkerCheck func(ctx context.Context, g Graphish, marker KnownMarker, msg pr.Message, target Terminal, src MarkerSource)
+
+// MarkerSource finds where a marker (and its parameters) came from in the
+// KDL source, if it was compiled from source.
+type MarkerSource struct {
+	g *Graph
+	node *Node
+	// path is the path to the marker's attribute in the node's partial.
+	path []int32
+}
+
+// In points errors in the given context at the marker.
+func (s MarkerSource) In(ctx context.Context) context.Context {
+	return s.g.inSource(ctx, s.node, s.path)
+}
+
+// InParam points errors in the given context at the given parameter of
+// the marker (or the marker as a whole, if we don't know where the
+// parameter is).
+func (s MarkerSource) InParam(ctx context.Context, field pr.FieldDescriptor) context.Context {
+	return s.g.inSource(ctx, s.node, appendPath(s.path, fieldNumber(&any.Any{}, "value"), int32(field.Number())))
+}
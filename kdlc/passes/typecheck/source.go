@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package typecheck
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+	pr "google.golang.org/protobuf/reflect/protoreflect"
+
+	"k8s.io/idl/kdlc/parser/trace"
+)
+
+// inSource points errors in the given context at the KDL for the node at
+// the given path in a file's partial, using the partial's source map.  Not
+// every node is in the source map, so this is the closest mapped node that
+// contains it.  Files without source (i.e. loaded from cKDL) are left
+// as-is.
+func (g *Graph) inSource(ctx context.Context, node *Node, path []int32) context.Context {
+	raw, haveSource := g.Sources[node.Path]
+	if !haveSource {
+		return ctx
+	}
+	input := string(raw)
+
+	// the innermost location is the one with the longest path that's a
+	// prefix of ours
+	var bestPath, bestSpan []int32
+	for _, loc := range node.Partial.SourceMap {
+		if len(loc.Path) > len(path) || (bestSpan != nil && len(loc.Path) <= len(bestPath)) {
+			continue
+		}
+		if !isPathPrefix(loc.Path, path) {
+			continue
+		}
+		if len(loc.Span) < 2 || loc.Span[0] < 0 || loc.Span[0] > loc.Span[1] || int(loc.Span[1]) > len(input) {
+			continue
+		}
+		bestPath, bestSpan = loc.Path, loc.Span
+	}
+	if bestSpan == nil {
+		return ctx
+	}
+	span := trace.SpanAt(node.Path, input, int(bestSpan[0]), int(bestSpan[1]))
+	return trace.InSpan(trace.WithFullInput(ctx, input), span)
+}
+
+func isPathPrefix(prefix, path []int32) bool {
+	for i, item := range prefix {
+		if path[i] != item {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldNumber returns the number of the named field of the given message,
+// for building source map paths.
+func fieldNumber(msg proto.Message, name pr.Name) int32 {
+	return int32(msg.ProtoReflect().Descriptor().Fields().ByName(name).Number())
+}
+
+// appendPath returns a copy of the given path with the given items added,
+// so that paths built from a common prefix don't share storage.
+func appendPath(path []int32, items ...int32) []int32 {
+	res := make([]int32, 0, len(path)+len(items))
+	res = append(res, path...)
+	return append(res, items...)
+}
@@ -0,0 +1,307 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+
+	"k8s.io/idl/backends/common/request"
+)
+
+// project is a project file (kdl.yaml by default), which records the
+// flags for a build so that `kdlc build` can reproduce it.  Relative
+// filesystem paths are relative to the directory containing the file.
+//
+//   importRoots: [apis]
+//   roots: [widgets/v1/widgets.kdl]
+//   targets:
+//     crds:
+//       backend: tocrd
+//       types: [widgets/v1::Widget]
+//       outputDir: config/crd
+//     go:
+//       backend: tokgo
+//       flags:
+//         apply-configurations: true
+//         go-package: [widgets/v1=example.com/widgets/v1]
+//       outputDir: apis
+type project struct {
+	// ImportRoots are the root KDL & cKDL import paths (-i), defaulting to
+	// the project directory.
+	ImportRoots []string `yaml:"importRoots"`
+	// ImportBundles are cKDL bundles to import from (-B).
+	ImportBundles []string `yaml:"importBundles"`
+	// ImportPartials maps KDL virtual paths to cKDL partial files to import
+	// them from (-I).
+	ImportPartials map[string]string `yaml:"importPartials"`
+	// Cache is the cache behavior (--cache).
+	Cache string `yaml:"cache"`
+	// Roots are the virtual paths of the KDL files to compile.
+	Roots []string `yaml:"roots"`
+	// Targets are the named outputs that can be built.
+	Targets map[string]projectTarget `yaml:"targets"`
+}
+
+// projectTarget is a single output (-o), along with its arguments.
+type projectTarget struct {
+	// Backend is the output format to run.
+	Backend string `yaml:"backend"`
+	// Types are the types to generate for (-t).
+	Types []string `yaml:"types"`
+	// Flags are the backend options (-f).  Options that can be repeated
+	// may be given as a list.
+	Flags map[string]flagValues `yaml:"flags"`
+	// OutputDir is where to write files to (-d), defaulting to the
+	// project directory.
+	OutputDir string `yaml:"outputDir"`
+}
+
+// flagValues is the value of a backend option, which may be a single
+// scalar or a list of them.
+type flagValues []string
+
+func (v *flagValues) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*v = flagValues{node.Value}
+	case yaml.SequenceNode:
+		vals := make(flagValues, len(node.Content))
+		for i, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: option values must be strings, booleans, or integers", item.Line)
+			}
+			vals[i] = item.Value
+		}
+		*v = vals
+	default:
+		return fmt.Errorf("line %d: option values must be a single value or a list of them", node.Line)
+	}
+	return nil
+}
+
+// projectFile is a loaded project, along with what's needed to point
+// errors at the right place in it.
+type projectFile struct {
+	project
+	path string
+	dir string
+	root *yaml.Node
+}
+
+// loadProject reads & validates the project file at the given path.
+func loadProject(path string) (*projectFile, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read project file: %w", err)
+	}
+
+	file := &projectFile{path: path, dir: filepath.Dir(path), root: new(yaml.Node)}
+	if err := yaml.Unmarshal(raw, file.root); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file.project); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s: project file is empty", path)
+		}
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if errs := file.validate(); len(errs) > 0 {
+		msgs := make([]string, len(errs))
+		for i, err := range errs {
+			msgs[i] = err.Error()
+		}
+		return nil, fmt.Errorf("invalid project file:\n\t%s", strings.Join(msgs, "\n\t"))
+	}
+	return file, nil
+}
+
+// errorf returns an error that points at the given field of the project
+// file (or as close to it as exists).
+func (p *projectFile) errorf(fieldPath []string, format string, args ...interface{}) error {
+	line := 0
+	node := p.root
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+		line = node.Line
+	}
+	for _, field := range fieldPath {
+		if node == nil || node.Kind != yaml.MappingNode {
+			break
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == field {
+				next = node.Content[i+1]
+				line = node.Content[i].Line
+				break
+			}
+		}
+		node = next
+	}
+	return fmt.Errorf("%s:%d: %s: %s", p.path, line, strings.Join(fieldPath, "."), fmt.Sprintf(format, args...))
+}
+
+func (p *projectFile) validate() []error {
+	var errs []error
+	if len(p.Roots) == 0 {
+		errs = append(errs, p.errorf([]string{"roots"}, "at least one root file is required"))
+	}
+	if p.Cache != "" {
+		var cache cacheBehaviorVal
+		if err := cache.Set(p.Cache); err != nil {
+			errs = append(errs, p.errorf([]string{"cache"}, "%v", err))
+		} else if cache.Behavior != "none" && cache.Behavior != "alongside-always" {
+			// the others aren't implemented by the compiler yet
+			errs = append(errs, p.errorf([]string{"cache"}, "cache behavior %q is not supported yet, expected none or alongside-always", p.Cache))
+		}
+	}
+	for kdlPath, ckdlPath := range p.ImportPartials {
+		if ckdlPath == "" {
+			errs = append(errs, p.errorf([]string{"importPartials", kdlPath}, "a cKDL path is required"))
+		}
+	}
+
+	if len(p.Targets) == 0 {
+		errs = append(errs, p.errorf([]string{"targets"}, "at least one target is required"))
+	}
+	for _, name := range p.targetNames() {
+		target := p.Targets[name]
+		if target.Backend == "" {
+			errs = append(errs, p.errorf([]string{"targets", name, "backend"}, "a backend is required"))
+		}
+		if _, err := request.ParseTypes(target.Types...); err != nil {
+			errs = append(errs, p.errorf([]string{"targets", name, "types"}, "%v", err))
+		}
+		for key := range target.Flags {
+			if key == "" {
+				errs = append(errs, p.errorf([]string{"targets", name, "flags"}, "option names may not be empty"))
+			}
+		}
+	}
+	return errs
+}
+
+func (p *projectFile) targetNames() []string {
+	names := make([]string, 0, len(p.Targets))
+	for name := range p.Targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// relPath resolves a path from the project file against its directory.
+func (p *projectFile) relPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(p.dir, path)
+}
+
+// BuildConfig returns the build for the given targets, or all of them if
+// none are given.
+func (p *projectFile) BuildConfig(targetNames []string) (buildConfig, error) {
+	cfg := buildConfig{
+		Roots: p.Roots,
+		Cache: cacheBehaviorVal{Behavior: "none"},
+	}
+	for _, root := range p.ImportRoots {
+		cfg.ImportPaths = append(cfg.ImportPaths, p.relPath(root))
+	}
+	if len(cfg.ImportPaths) == 0 {
+		cfg.ImportPaths = []string{p.dir}
+	}
+	for _, bundle := range p.ImportBundles {
+		cfg.ImportBundles = append(cfg.ImportBundles, p.relPath(bundle))
+	}
+	kdlPaths := make([]string, 0, len(p.ImportPartials))
+	for kdlPath := range p.ImportPartials {
+		kdlPaths = append(kdlPaths, kdlPath)
+	}
+	sort.Strings(kdlPaths)
+	for _, kdlPath := range kdlPaths {
+		cfg.ImportPartials.Keys = append(cfg.ImportPartials.Keys, kdlPath)
+		cfg.ImportPartials.Values = append(cfg.ImportPartials.Values, p.relPath(p.ImportPartials[kdlPath]))
+	}
+	if p.Cache != "" {
+		if err := cfg.Cache.Set(p.Cache); err != nil {
+			return cfg, p.errorf([]string{"cache"}, "%v", err)
+		}
+	}
+
+	if len(targetNames) == 0 {
+		targetNames = p.targetNames()
+	}
+	for _, name := range targetNames {
+		target, known := p.Targets[name]
+		if !known {
+			return cfg, fmt.Errorf("%s: no target named %q (known targets: %s)", p.path, name, strings.Join(p.targetNames(), ", "))
+		}
+		out := &output{
+			Name: name,
+			Format: target.Backend,
+			Types: target.Types,
+			Dir: p.dir,
+		}
+		if target.OutputDir == "-" {
+			out.Dir = "-"
+		} else if target.OutputDir != "" {
+			out.Dir = p.relPath(target.OutputDir)
+		}
+		flagNames := make([]string, 0, len(target.Flags))
+		for flagName := range target.Flags {
+			flagNames = append(flagNames, flagName)
+		}
+		sort.Strings(flagNames)
+		for _, flagName := range flagNames {
+			for _, val := range target.Flags[flagName] {
+				out.Flags.Keys = append(out.Flags.Keys, flagName)
+				out.Flags.Values = append(out.Flags.Values, val)
+			}
+		}
+		cfg.Outputs = append(cfg.Outputs, out)
+	}
+	return cfg, nil
+}
+
+// buildMain implements `kdlc build [TARGET...]`.
+func buildMain(args []string) {
+	flags := flag.NewFlagSet("build", flag.ExitOnError)
+	configPath := flags.StringP("config", "c", "kdl.yaml", "path to the project file")
+	flags.CountVarP(verbose, "verbose", "v", verboseUsage)
+	flags.BoolVar(verifyOnly, "verify", false, "check that the files in each output directory match what would be generated, instead of writing them, exiting non-zero if they don't")
+	flags.BoolVar(showDiff, "diff", false, "like --verify, but also print a unified diff of each file that doesn't match")
+	flags.BoolVar(prune, "prune", false, "remove files that an output generated previously but no longer does")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s build [FLAGS...] [TARGET...]\n", os.Args[0])
+		fmt.Fprintln(os.Stderr, "where TARGET are the names of targets in the project file to build (defaults to all of them)")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	proj, err := loadProject(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	cfg, err := proj.BuildConfig(flags.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	build(cfg)
+}
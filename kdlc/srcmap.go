@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package main
+
+import (
+	"fmt"
+	"io"
+
+	ir "k8s.io/idl/ckdl-ir/goir"
+	irb "k8s.io/idl/ckdl-ir/goir/backend"
+	"k8s.io/idl/kdlc/parser/trace"
+)
+
+// field numbers that lead from a bundle to the partial for one of its
+// files (see envelope.proto)
+const (
+	bundleVirtualFilesField = 1
+	bundleFileContentsField = 2
+)
+
+// sourceLocator finds the KDL that nodes sent back in backend logs came
+// from.  Nodes are paths from the bundle that was sent to the backend,
+// which we look up in the source map of the partial they point into.
+type sourceLocator struct {
+	bundle *ir.Bundle
+	// sources are the contents of the files compiled from source this
+	// time around -- files loaded from cKDL have no source to show.
+	sources map[string][]byte
+}
+
+// sourceLoc is the location of a node, as best we know it.
+type sourceLoc struct {
+	// file is the virtual path of the file the node is in, if known.
+	file string
+	// span is the source span of the innermost mapped node containing the
+	// node, if known.
+	span *trace.Span
+	// input is the contents of file, if known.
+	input *string
+}
+
+func (l sourceLoc) String() string {
+	switch {
+	case l.span != nil:
+		return l.span.Start.Start.String()
+	case l.file != "":
+		return l.file
+	default:
+		return "<unknown location>"
+	}
+}
+
+// locate finds the location of the node at the given path.  Not every
+// node is in the source map, so this is the location of the closest
+// mapped node that contains it.
+func (l sourceLocator) locate(node *irb.Log_Trace_Node) sourceLoc {
+	path := node.GetPath()
+	if len(path) < 3 || path[0] != bundleVirtualFilesField || path[2] != bundleFileContentsField {
+		return sourceLoc{}
+	}
+	if path[1] < 0 || int(path[1]) >= len(l.bundle.VirtualFiles) {
+		return sourceLoc{}
+	}
+	file := l.bundle.VirtualFiles[path[1]]
+	res := sourceLoc{file: file.Name}
+	raw, haveSource := l.sources[file.Name]
+	if !haveSource {
+		return res
+	}
+	input := string(raw)
+	res.input = &input
+
+	// the innermost location is the one with the longest path that's a
+	// prefix of ours
+	path = path[3:]
+	var best *ir.Location
+	for _, loc := range file.Contents.GetSourceMap() {
+		if len(loc.Path) > len(path) || (best != nil && len(loc.Path) <= len(best.Path)) {
+			continue
+		}
+		if !isPathPrefix(loc.Path, path) {
+			continue
+		}
+		if len(loc.Span) < 2 || loc.Span[0] < 0 || loc.Span[0] > loc.Span[1] || int(loc.Span[1]) > len(input) {
+			continue
+		}
+		best = loc
+	}
+	if best != nil {
+		span := trace.SpanAt(file.Name, input, int(best.Span[0]), int(best.Span[1]))
+		res.span = &span
+	}
+	return res
+}
+
+func isPathPrefix(prefix, path []int32) bool {
+	for i, item := range prefix {
+		if path[i] != item {
+			return false
+		}
+	}
+	return true
+}
+
+// print writes out where the given location is, with a snippet of the
+// source if we have it.
+func (l sourceLoc) print(out io.Writer, desc string) {
+	fmt.Fprintf(out, "  ...%s %s", desc, l)
+	if l.span == nil || l.input == nil {
+		if l.file != "" {
+			fmt.Fprint(out, " (no source available)")
+		}
+		fmt.Fprintln(out, "")
+		return
+	}
+	fmt.Fprintf(out, "\n\t%s\n", trace.Snippet(*l.span, *l.input))
+}
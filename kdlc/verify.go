@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	irb "k8s.io/idl/ckdl-ir/goir/backend"
+)
+
+// verifier compares the files backends produce with what's on disk,
+// instead of writing them out.  It's not safe for concurrent use (the
+// response writers serialize access to it).
+type verifier struct {
+	// ShowDiff prints a unified diff of each out-of-date file to stdout.
+	ShowDiff bool
+
+	problems int
+}
+
+// Check compares a file produced by the given output with the copy on disk.
+func (v *verifier) Check(out *output, file *irb.File) {
+	diskPath, err := outputPath(out.Dir, file.Name)
+	if err != nil {
+		v.report(out, fmt.Sprintf("invalid (%v)", err), file.Name)
+		return
+	}
+	existing, err := ioutil.ReadFile(diskPath)
+	switch {
+	case os.IsNotExist(err):
+		v.report(out, "missing", file.Name)
+		if v.ShowDiff {
+			v.printDiff(file.Name, nil, file.Contents)
+		}
+	case err != nil:
+		v.report(out, fmt.Sprintf("unreadable (%v)", err), file.Name)
+	case !bytes.Equal(existing, file.Contents):
+		v.report(out, "out of date", file.Name)
+		if v.ShowDiff {
+			v.printDiff(file.Name, existing, file.Contents)
+		}
+	}
+}
+
+// Stale reports files that the given output generated previously, but no
+// longer does.
+func (v *verifier) Stale(out *output, names []string) {
+	for _, name := range names {
+		diskPath, err := outputPath(out.Dir, name)
+		if err != nil {
+			// not something we'd have written, so not our problem
+			continue
+		}
+		if _, err := os.Stat(diskPath); os.IsNotExist(err) {
+			continue
+		}
+		v.report(out, "stale (no longer generated)", name)
+	}
+}
+
+// Failed indicates whether any generated files didn't match.
+func (v *verifier) Failed() bool {
+	return v.problems > 0
+}
+
+func (v *verifier) report(out *output, problem, name string) {
+	v.problems++
+	fmt.Fprintf(os.Stderr, "[%s] %s: %s\n", out.DisplayName(), problem, filepath.Join(out.Dir, filepath.FromSlash(name)))
+}
+
+// printDiff prints a unified diff from the existing file (nil if missing)
+// to the generated one.
+func (v *verifier) printDiff(name string, existing, generated []byte) {
+	diffSpec := difflib.UnifiedDiff{
+		B: difflib.SplitLines(string(generated)),
+		FromFile: "a/" + name,
+		ToFile: "b/" + name,
+		Context: 3,
+	}
+	if existing == nil {
+		diffSpec.FromFile = "/dev/null"
+	} else {
+		diffSpec.A = difflib.SplitLines(string(existing))
+	}
+	diff, err := difflib.GetUnifiedDiffString(diffSpec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to diff %s: %v\n", name, err)
+		return
+	}
+	fmt.Print(diff)
+}
@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright 2021 The Kubernetes Authors
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// outputPath resolves a file name from a backend (or the manifest) to a
+// path under the given output directory.  Backends may well be third-party
+// commands, so names have to be relative, slash-separated, and stay within
+// the directory.
+func outputPath(dir, name string) (string, error) {
+	switch {
+	case name == "":
+		return "", fmt.Errorf("file name is empty")
+	case strings.Contains(name, `\`):
+		return "", fmt.Errorf("file name %q must use forward slashes", name)
+	case path.IsAbs(name) || filepath.IsAbs(name) || filepath.VolumeName(name) != "":
+		return "", fmt.Errorf("file name %q must be relative to the output directory", name)
+	}
+	clean := path.Clean(name)
+	if clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("file name %q is outside the output directory", name)
+	}
+	if clean == "." || clean == manifestName {
+		return "", fmt.Errorf("file name %q is reserved", name)
+	}
+	return filepath.Join(dir, filepath.FromSlash(clean)), nil
+}
+
+// checkWithin makes sure that the given directory, once symlinks are
+// resolved, is still within the output directory.
+func checkWithin(outDir, dir string) error {
+	realOut, err := filepath.EvalSymlinks(outDir)
+	if err != nil {
+		return err
+	}
+	realDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(realOut, realDir)
+	if err != nil {
+		return err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("%s is outside the output directory (via a symlink)", dir)
+	}
+	return nil
+}
+
+// writeOutputFile writes a file from a backend under the output directory,
+// creating parent directories as needed.  The contents are written to a
+// temporary file first and renamed into place, so an interrupted write
+// never leaves a partial file behind.
+func writeOutputFile(outDir, name string, contents []byte) error {
+	dest, err := outputPath(outDir, name)
+	if err != nil {
+		return err
+	}
+	if err := mkdirWithin(outDir, filepath.Dir(dest)); err != nil {
+		return err
+	}
+	return writeFileAtomic(dest, contents)
+}
+
+// mkdirWithin creates the given directory under the output directory one
+// component at a time, checking each existing component before creating
+// anything inside it, so that a symlink can't get us to create directories
+// outside the output directory.
+func mkdirWithin(outDir, dir string) error {
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("unable to create directory: %w", err)
+	}
+	rel, err := filepath.Rel(outDir, dir)
+	if err != nil {
+		return err
+	}
+	current := outDir
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		if part == "." {
+			continue
+		}
+		current = filepath.Join(current, part)
+		if _, err := os.Lstat(current); err == nil {
+			if err := checkWithin(outDir, current); err != nil {
+				return err
+			}
+			continue
+		}
+		// the parent's already been checked, so this lands within the
+		// output directory
+		if err := os.Mkdir(current, 0755); err != nil && !os.IsExist(err) {
+			return fmt.Errorf("unable to create directory: %w", err)
+		}
+	}
+	return checkWithin(outDir, dir)
+}
+
+// writeFileAtomic writes a file via a temporary file in the same directory
+// & a rename.
+func writeFileAtomic(dest string, contents []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(dest), "."+filepath.Base(dest)+".tmp-")
+	if err != nil {
+		return fmt.Errorf("unable to create temporary file: %w", err)
+	}
+	tmpName := tmp.Name()
+	_, err = tmp.Write(contents)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpName, 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmpName, dest)
+	}
+	if err != nil {
+		os.Remove(tmpName)
+		return fmt.Errorf("unable to write %s: %w", dest, err)
+	}
+	return nil
+}
+
+// pruneOutputFile removes a file that's no longer generated, along with
+// any parent directories (short of the output directory) that it leaves
+// empty.
+func pruneOutputFile(outDir, name string) error {
+	target, err := outputPath(outDir, name)
+	if err != nil {
+		return err
+	}
+	parent := filepath.Dir(target)
+	if err := checkWithin(outDir, parent); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	absOut, err := filepath.Abs(outDir)
+	if err != nil {
+		return nil
+	}
+	for dir := parent; ; dir = filepath.Dir(dir) {
+		absDir, err := filepath.Abs(dir)
+		if err != nil || absDir == absOut || len(absDir) <= len(absOut) {
+			return nil
+		}
+		// Remove only succeeds on empty directories
+		if os.Remove(dir) != nil {
+			return nil
+		}
+	}
+}